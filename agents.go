@@ -0,0 +1,103 @@
+package maze
+
+import "fmt"
+
+// Agent is a robot that MultiPath routes from Start to Goal.
+type Agent struct {
+	Start Coord
+	Goal  Coord
+}
+
+// Plan is the collision-free timed paths of the agents given to MultiPath.
+type Plan struct {
+	// Paths contains the timed path of every agent, in the order of the agents.
+	// An agent stays at its goal after the last step of its path.
+	Paths [][]TimedStep
+
+	// Makespan is the step when the last agent arrives at its goal.
+	Makespan int
+
+	// SumOfCosts is the sum of the steps when every agent arrives at its goal.
+	SumOfCosts int
+}
+
+// The method MultiPath returns timed paths for all agents at once, where no two agents
+// are at the same vertex at the same step and no two agents swap vertices with each
+// other in one step. The agents move like the path of TimedPath, i.e. every step takes
+// one time step and an agent can wait in place, and they also avoid the vertices
+// blocked by their schedules. The start- and finishVertices of the graph aren't used.
+//
+// The paths are found with prioritized planning: the agents are planned one at a time
+// in the given order, with the space-time BFS of TimedPath, and every planned path
+// reserves its vertices and edges for the rest of the agents. An agent is parked at
+// its goal when it arrives, so it only arrives when no earlier agent passes the goal
+// later on. Prioritized planning is fast but not complete, so putting the agents
+// with the longest paths first can make a plan possible.
+//
+// MultiPath returns an error if a start or goal is outside of the graph or is an
+// obstacle, and an error wrapping ErrNoPath if two agents share a start or a goal or
+// if an agent can't reach its goal within horizon steps.
+func (g *Graph) MultiPath(agents []Agent, horizon int) (Plan, error) {
+	starts, goals := make(map[int]int), make(map[int]int)
+	for k, a := range agents {
+		for _, c := range []Coord{a.Start, a.Goal} {
+			if err := g.checkBounds(c); err != nil {
+				return Plan{}, fmt.Errorf("agent %d: %w", k+1, err)
+			}
+			if g.cells[g.index(c)]&obstacleFlag != 0 {
+				return Plan{}, fmt.Errorf("%w: agent %d at %v", ErrIsObstacle, k+1, c)
+			}
+		}
+		if j, found := starts[g.index(a.Start)]; found {
+			return Plan{}, fmt.Errorf("%w: agents %d and %d start at %v", ErrNoPath, j+1, k+1, a.Start)
+		}
+		if j, found := goals[g.index(a.Goal)]; found {
+			return Plan{}, fmt.Errorf("%w: agents %d and %d have the goal %v", ErrNoPath, j+1, k+1, a.Goal)
+		}
+		starts[g.index(a.Start)], goals[g.index(a.Goal)] = k, k
+	}
+
+	// reserved contains the states t*n + i of the planned paths, moves the edges they
+	// take keyed by the step and the vertices, parked the step every planned agent
+	// arrives at its goal and last the last step any planned path is at a vertex.
+	n := len(g.cells)
+	reserved := make(map[int]bool)
+	moves := make(map[[3]int]bool)
+	parked := make(map[int]int)
+	last := make(map[int]int)
+	blocked := func(i int, t int) bool {
+		if p, found := parked[i]; found && t >= p {
+			return true
+		}
+		return reserved[t*n+i] || g.blockedAt(i, t)
+	}
+	crosses := func(a int, x int, t int) bool {
+		return moves[[3]int{t, x, a}]
+	}
+
+	plan := Plan{Paths: make([][]TimedStep, len(agents))}
+	for k, a := range agents {
+		goal := g.index(a.Goal)
+		path := g.timedSearch([]int{g.index(a.Start)}, horizon, blocked, crosses, func(i int, t int) bool {
+			l, found := last[i]
+			return i == goal && (!found || t > l)
+		})
+		if path == nil {
+			return Plan{}, fmt.Errorf("%w: agent %d from %v to %v within %d steps", ErrNoPath, k+1, a.Start, a.Goal, horizon)
+		}
+		for s, step := range path {
+			i := g.index(step.Coord)
+			reserved[step.Step*n+i] = true
+			last[i] = max(last[i], step.Step)
+			if s > 0 && path[s-1].Coord != step.Coord {
+				moves[[3]int{path[s-1].Step, g.index(path[s-1].Coord), i}] = true
+			}
+		}
+		arrival := path[len(path)-1].Step
+		parked[goal] = arrival
+		plan.Paths[k] = path
+		plan.Makespan = max(plan.Makespan, arrival)
+		plan.SumOfCosts += arrival
+	}
+	return plan, nil
+}
@@ -0,0 +1,82 @@
+package maze
+
+import (
+	"errors"
+	"testing"
+)
+
+// checkPlan reports the first vertex or swap conflict between the paths of the
+// plan, where an agent stays at the last vertex of its path.
+func checkPlan(t *testing.T, plan Plan) {
+	t.Helper()
+	at := func(path []TimedStep, step int) Coord {
+		c := path[0].Coord
+		for _, s := range path {
+			if s.Step <= step {
+				c = s.Coord
+			}
+		}
+		return c
+	}
+	for step := 0; step <= plan.Makespan; step++ {
+		for a := range plan.Paths {
+			for b := a + 1; b < len(plan.Paths); b++ {
+				pa, pb := plan.Paths[a], plan.Paths[b]
+				if at(pa, step) == at(pb, step) {
+					t.Errorf("agents %d and %d are at %v at step %d", a+1, b+1, at(pa, step), step)
+				}
+				if step > 0 && at(pa, step) == at(pb, step-1) && at(pb, step) == at(pa, step-1) {
+					t.Errorf("agents %d and %d swap vertices at step %d", a+1, b+1, step)
+				}
+			}
+		}
+	}
+}
+
+func TestMultiPath(t *testing.T) {
+	g, _ := New(3, 3)
+	agents := []Agent{
+		{Coord{2, 1}, Coord{2, 3}},
+		{Coord{2, 3}, Coord{2, 1}},
+		{Coord{1, 2}, Coord{3, 2}},
+	}
+	plan, err := g.MultiPath(agents, 20)
+	if err != nil {
+		t.Fatalf("g.MultiPath(...) error = %v, expected: <nil>", err)
+	}
+	checkPlan(t, plan)
+	for k, a := range agents {
+		path := plan.Paths[k]
+		if path[0] != (TimedStep{a.Start, 0}) || path[len(path)-1].Coord != a.Goal {
+			t.Errorf("agent %d has the path %v, expected: %v to %v", k+1, path, a.Start, a.Goal)
+		}
+	}
+	if plan.Paths[0][len(plan.Paths[0])-1].Step != 2 {
+		t.Errorf("agent 1 has the path %v, expected to arrive at step 2", plan.Paths[0])
+	}
+	sum, makespan := 0, 0
+	for _, path := range plan.Paths {
+		sum += path[len(path)-1].Step
+		makespan = max(makespan, path[len(path)-1].Step)
+	}
+	if plan.SumOfCosts != sum || plan.Makespan != makespan {
+		t.Errorf("plan has the sum of costs %v and makespan %v, expected: %v, %v", plan.SumOfCosts, plan.Makespan, sum, makespan)
+	}
+
+	g, _ = New(1, 3)
+	var tests = []struct {
+		agents []Agent
+		exp    error
+	}{
+		{[]Agent{{Coord{1, 1}, Coord{1, 2}}, {Coord{1, 3}, Coord{1, 1}}}, ErrNoPath},
+		{[]Agent{{Coord{1, 1}, Coord{1, 2}}, {Coord{1, 1}, Coord{1, 3}}}, ErrNoPath},
+		{[]Agent{{Coord{1, 1}, Coord{1, 2}}, {Coord{1, 3}, Coord{1, 2}}}, ErrNoPath},
+		{[]Agent{{Coord{1, 1}, Coord{1, 4}}}, ErrOutOfBounds},
+		{[]Agent{{Coord{1, 2}, Coord{1, 3}}, {Coord{1, 1}, Coord{1, 2}}}, nil},
+	}
+	for _, e := range tests {
+		if _, err := g.MultiPath(e.agents, 10); !errors.Is(err, e.exp) {
+			t.Errorf("g.MultiPath(%v, 10) error = %v, expected: %v", e.agents, err, e.exp)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package maze
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Heuristic estimates the distance from the vertex a to the vertex b, it's
+// used by AStar to decide which vertex to expand next.
+//
+// A heuristic is admissible if it never overestimates the distance, and AStar
+// then finds a shortest path. Manhattan, Euclidean, Chebyshev and Zero are
+// admissible for graphs with orthogonal edges, but only Chebyshev and Zero
+// when the graph is created WithDiagonals, and only Hex, Chebyshev and Zero
+// when it's created WithHex, since a step to the north-east or south-west
+// changes both coordinates. Only Zero is admissible when the graph has portals,
+// since a portal can be shorter than the distance between its vertices.
+type Heuristic func(a Coord, b Coord) float64
+
+// Manhattan returns the distance between a and b when only moving
+// horizontally and vertically, i.e. |y1-y2| + |x1-x2|.
+func Manhattan(a Coord, b Coord) float64 {
+	return float64(abs(a.Row-b.Row) + abs(a.Col-b.Col))
+}
+
+// Euclidean returns the straight line distance between a and b.
+func Euclidean(a Coord, b Coord) float64 {
+	return math.Hypot(float64(a.Row-b.Row), float64(a.Col-b.Col))
+}
+
+// Chebyshev returns the distance between a and b when diagonal moves are
+// allowed, i.e. max(|y1-y2|, |x1-x2|).
+func Chebyshev(a Coord, b Coord) float64 {
+	dy, dx := abs(a.Row-b.Row), abs(a.Col-b.Col)
+	if dy > dx {
+		return float64(dy)
+	}
+	return float64(dx)
+}
+
+// Zero always returns 0, which makes AStar expand the vertices in the same
+// order of increasing distance as the BFS of GetFastestPath.
+func Zero(a Coord, b Coord) float64 {
+	return 0
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// The method AStar returns the shortest distance between the start- and finishvertex,
+// a slice of the coordinates on the shortest path and the number of vertices that
+// were expanded to find it.
+//
+// AStar uses the heuristic h to expand the vertices closest to the finishVertex first,
+// so fewer vertices are expanded than by the BFS of GetFastestPath when the start-
+// and finishVertex are close together. If h is admissible, see Heuristic, the distance
+// is the same as the one returned by GetFastestPath. A nil h is the same as Zero.
+//
+// With several finishVertices the heuristic distance of a vertex is the smallest
+// one to any of them, so the path goes to the nearest finishVertex.
+//
+// AStar returns an error wrapping ErrNoPath if the start- or finishVertex
+// isn't set or if there's no path between them.
+func (g *Graph) AStar(h Heuristic) (int, []Coord, int, error) {
+	if err := g.checkEndpoints(); err != nil {
+		return 0, nil, 0, err
+	}
+	if h == nil {
+		h = Zero
+	}
+	predecessor, finish, distance, expanded := g.fastestPathAStar(h)
+	if finish < 0 {
+		return 0, nil, expanded, ErrNoPath
+	}
+	return distance, g.tracePath(predecessor, finish), expanded, nil
+}
+
+// fastestPathAStar expands the vertices in order of the distance from the startVertices
+// plus the heuristic distance to the nearest finishVertex, until a finishVertex is reached.
+// It returns the predecessor of every reached vertex, the index of the reached
+// finishVertex or -1 if none was reached, its distance and the number of expanded vertices.
+func (g *Graph) fastestPathAStar(h Heuristic) ([]int32, int, int, int) {
+	predecessor := make([]int32, len(g.cells))
+	distance := make([]int, len(g.cells))
+	for i := range predecessor {
+		predecessor[i] = -1
+		distance[i] = math.MaxInt
+	}
+	finishes := g.coords(g.finishes)
+	estimate := func(i int) float64 {
+		c := g.coord(i)
+		est := math.Inf(1)
+		for _, f := range finishes {
+			est = math.Min(est, h(c, f))
+		}
+		return est
+	}
+
+	queue := &costHeap{}
+	for _, i := range g.starts {
+		predecessor[i] = int32(i)
+		distance[i] = 0
+		heap.Push(queue, costItem{index: i, priority: estimate(i)})
+	}
+	expanded := 0
+
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(costItem)
+		a := item.index
+		if item.cost > distance[a] {
+			continue
+		}
+		if g.cells[a]&finishFlag != 0 {
+			return predecessor, a, distance[a], expanded
+		}
+		expanded++
+		for d := range directions {
+			if g.cells[a]&passage(d) == 0 {
+				continue
+			}
+			x := g.step(a, d)
+			if dist := distance[a] + 1; dist < distance[x] {
+				distance[x] = dist
+				predecessor[x] = int32(a)
+				heap.Push(queue, costItem{index: x, cost: dist, priority: float64(dist) + estimate(x)})
+			}
+		}
+		for _, p := range g.portals[a] {
+			x := p.to
+			if dist := distance[a] + p.cost; dist < distance[x] && g.cells[x]&obstacleFlag == 0 {
+				distance[x] = dist
+				predecessor[x] = int32(a)
+				heap.Push(queue, costItem{index: x, cost: dist, priority: float64(dist) + estimate(x)})
+			}
+		}
+	}
+	return predecessor, -1, 0, expanded
+}
@@ -0,0 +1,81 @@
+package maze
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHeuristics(t *testing.T) {
+	a, b := Coord{1, 1}, Coord{4, 5}
+	var tests = []struct {
+		name string
+		h    Heuristic
+		exp  float64
+	}{
+		{"Manhattan", Manhattan, 7},
+		{"Euclidean", Euclidean, 5},
+		{"Chebyshev", Chebyshev, 4},
+		{"Zero", Zero, 0},
+	}
+	for _, e := range tests {
+		if res := e.h(a, b); res != e.exp {
+			t.Errorf("%v(%v, %v) = %v, expected: %v", e.name, a, b, res, e.exp)
+		}
+	}
+}
+
+func TestAStar(t *testing.T) {
+	g := NewGraph(5, 3)
+	g.AddStart(1, 1)
+	g.AddFinish(1, 3)
+	g.AddObstacle(1, 2)
+	g.AddObstacle(2, 2)
+	g.AddObstacle(4, 2)
+	for _, h := range []Heuristic{Manhattan, Euclidean, Chebyshev, Zero, nil} {
+		dist, path, _, err := g.AStar(h)
+		fastest, _ := g.GetFastestPath()
+		if err != nil || dist != fastest || len(path) != dist+1 || path[0] != (Coord{1, 1}) || path[dist] != (Coord{1, 3}) {
+			t.Errorf("g.AStar() = %v, %v, %v; expected: %v, [(1,1) ... (1,3)], <nil>", dist, path, err, fastest)
+		}
+	}
+
+	big := NewGraph(100, 100)
+	big.AddStart(50, 50)
+	big.AddFinish(50, 55)
+	_, _, manhattan, _ := big.AStar(Manhattan)
+	dist, _, zero, _ := big.AStar(Zero)
+	if dist != 5 || manhattan != 5 || zero <= manhattan {
+		t.Errorf("Error: AStar expanded %v vertices with Manhattan and %v with Zero.", manhattan, zero)
+	}
+
+	// A step to the north-east or south-west of a hex grid changes both coordinates,
+	// so only Hex, Chebyshev and Zero are admissible there.
+	// Manhattan finds a path of 6 steps in the first graph, where the shortest is 5.
+	layouts := [][]Coord{{{5, 4}, {5, 3}, {1, 3}, {2, 3}}}
+	for seed := 0; seed < 20; seed++ {
+		var obstacles []Coord
+		for k := 0; k < 10; k++ {
+			obstacles = append(obstacles, Coord{(seed*7+k*5)%6 + 1, (seed*3+k*11)%6 + 1})
+		}
+		layouts = append(layouts, obstacles)
+	}
+	for _, obstacles := range layouts {
+		hex, _ := New(6, 6, WithHex())
+		for _, c := range obstacles {
+			hex.AddObstacle(c.Row, c.Col)
+		}
+		hex.AddStart(2, 4)
+		hex.AddFinish(6, 4)
+		fastest, _ := hex.GetFastestPath()
+		for _, h := range []Heuristic{Hex, Chebyshev, Zero} {
+			if dist, _, _, _ := hex.AStar(h); dist != fastest {
+				t.Errorf("hex.AStar() = %v, expected: %v\n%v", dist, fastest, hex)
+			}
+		}
+	}
+
+	g.AddObstacle(3, 1)
+	if _, _, _, err := g.AStar(Manhattan); !errors.Is(err, ErrNoPath) {
+		t.Errorf("g.AStar() error = %v, expected: %v", err, ErrNoPath)
+	}
+}
@@ -0,0 +1,130 @@
+package maze
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Braiding is the result of Braid.
+type Braiding struct {
+	// Removed is the number of dead ends that were opened up.
+	Removed int
+
+	// DeadEnds is the number of dead ends left, i.e. open vertices with a single
+	// neighbour they can be reached from.
+	DeadEnds int
+
+	// Cycles is the number of independent loops in the maze, i.e. the number of
+	// edges minus the number of open vertices plus the number of separate regions.
+	// A perfect maze has no cycles, and every opened wall adds one.
+	Cycles int
+
+	// Before and After is the distance of GetFastestPath before and after the dead
+	// ends were removed, or 0 if the start- or finishVertex isn't set or there's no
+	// path. After is never larger than Before when there's a path before.
+	Before int
+	After  int
+}
+
+// The method Braid removes about ratio, 0-1, of the dead ends of the maze by removing
+// a wall of every removed dead end, which adds loops so there's more than one route
+// between some vertices. A wall to another dead end is removed if there is one, so
+// both are removed at once, and otherwise a random wall. A ratio of 1 removes every
+// dead end that has a wall to remove, which gives a braid maze without dead ends.
+//
+// Braid is meant for the mazes of Generate but works with any walls and obstacles.
+// Removing walls can only make the paths shorter, and the result has the distance
+// of GetFastestPath before and after, so it shows how much shorter the route got.
+// The same ratio and seed always remove the same walls of the same maze.
+//
+// Braid returns an error wrapping ErrInvalidArgument if ratio isn't 0-1 and an error
+// wrapping ErrUnsupported if the graph is created WithDiagonals, WithHex or WithWrap.
+func (g *Graph) Braid(ratio float64, seed int64) (Braiding, error) {
+	if g.dirs != orthogonal || g.hex || g.wrap != 0 {
+		return Braiding{}, fmt.Errorf("%w: only graphs with orthogonal edges that don't wrap can be braided", ErrUnsupported)
+	}
+	if !(ratio >= 0 && ratio <= 1) {
+		return Braiding{}, fmt.Errorf("%w: ratio %v", ErrInvalidArgument, ratio)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	before, _ := g.GetFastestPath()
+	var deadEnds []int
+	for i := range g.cells {
+		if g.isDeadEnd(i) {
+			deadEnds = append(deadEnds, i)
+		}
+	}
+	rng.Shuffle(len(deadEnds), func(a, b int) { deadEnds[a], deadEnds[b] = deadEnds[b], deadEnds[a] })
+
+	target := int(math.Round(ratio * float64(len(deadEnds))))
+	removed := 0
+	dirs := make([]int, 0, 4)
+	for _, i := range deadEnds {
+		if removed >= target {
+			break
+		}
+		if !g.isDeadEnd(i) {
+			continue
+		}
+		dirs = dirs[:0]
+		paired := false
+		for d := 0; d < 4; d++ {
+			j, ok := g.adjacent(i, d)
+			if !ok || g.cells[i]&wall(d) == 0 || g.cells[j]&obstacleFlag != 0 {
+				continue
+			}
+			if end := g.isDeadEnd(j); end && !paired {
+				dirs, paired = dirs[:0], true
+			} else if end != paired {
+				continue
+			}
+			dirs = append(dirs, d)
+		}
+		if len(dirs) == 0 {
+			continue
+		}
+		g.carve(i, dirs[rng.Intn(len(dirs))])
+		removed++
+		if paired {
+			removed++
+		}
+	}
+	g.refreshAll()
+
+	b := Braiding{Before: before}
+	b.After, _ = g.GetFastestPath()
+	open, edges := 0, 0
+	for i := range g.cells {
+		if g.cells[i]&obstacleFlag != 0 {
+			continue
+		}
+		open++
+		if g.isDeadEnd(i) {
+			b.DeadEnds++
+		}
+		for _, d := range []int{1, 2} {
+			if j, ok := g.adjacent(i, d); ok && (g.canPass(i, d) || g.canPass(j, opposite(d))) {
+				edges++
+			}
+		}
+	}
+	b.Removed = len(deadEnds) - b.DeadEnds
+	b.Cycles = edges - open + len(g.regions())
+	return b, nil
+}
+
+// isDeadEnd reports whether the vertex i is open and has exactly one adjencent
+// vertex it can be reached from or go to, see canPass.
+func (g *Graph) isDeadEnd(i int) bool {
+	if g.cells[i]&obstacleFlag != 0 {
+		return false
+	}
+	neighbours := 0
+	for d := 0; d < 4; d++ {
+		if j, ok := g.adjacent(i, d); ok && (g.canPass(i, d) || g.canPass(j, opposite(d))) {
+			neighbours++
+		}
+	}
+	return neighbours == 1
+}
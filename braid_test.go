@@ -0,0 +1,56 @@
+package maze
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBraid(t *testing.T) {
+	g, _ := New(15, 15)
+	g.Generate(RecursiveBacktracker, 3)
+	g.AddStart(1, 1)
+	g.AddFinish(15, 15)
+	initial, err := g.Braid(0, 1)
+	if err != nil {
+		t.Fatalf("g.Braid(0, 1) error = %v, expected: <nil>", err)
+	}
+	if initial.Removed != 0 || initial.Cycles != 0 || initial.DeadEnds == 0 || initial.Before == 0 || initial.After != initial.Before {
+		t.Fatalf("g.Braid(0, 1) = %+v, expected the perfect maze unchanged", initial)
+	}
+
+	before, ends := initial.After, initial.DeadEnds
+	for _, ratio := range []float64{0.5, 1} {
+		b, err := g.Braid(ratio, 1)
+		if err != nil {
+			t.Fatalf("g.Braid(%v, 1) error = %v, expected: <nil>", ratio, err)
+		}
+		if b.DeadEnds+b.Removed != ends || b.Cycles <= 0 {
+			t.Errorf("g.Braid(%v, 1) = %+v with %v dead ends before", ratio, b, ends)
+		}
+		if ratio == 1 && b.DeadEnds != 0 {
+			t.Errorf("g.Braid(1, 1) left %v dead ends:\n%v", b.DeadEnds, g)
+		}
+		if ratio == 0.5 && (b.Removed < ends/2-1 || b.Removed > ends/2+2) {
+			t.Errorf("g.Braid(0.5, 1) removed %v of %v dead ends", b.Removed, ends)
+		}
+		if b.Before != before || b.After == 0 || b.After >= b.Before {
+			t.Errorf("g.Braid(%v, 1) distance = %v -> %v, expected shorter than: %v", ratio, b.Before, b.After, before)
+		}
+		if !isConnected(g) {
+			t.Errorf("g.Braid(%v, 1) has unreachable vertices", ratio)
+		}
+		before, ends = b.After, b.DeadEnds
+	}
+
+	g, _ = New(1, 5)
+	if b, _ := g.Braid(1, 1); b.DeadEnds != 2 || b.Cycles != 0 || b.Before != 0 || b.After != 0 {
+		t.Errorf("g.Braid(1, 1) on a corridor = %+v, expected 2 dead ends, no cycles and no path", b)
+	}
+	if _, err := g.Braid(1.5, 1); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("g.Braid(1.5, 1) = %v, expected: %v", err, ErrInvalidArgument)
+	}
+	g, _ = New(3, 3, WithDiagonals(CornersAllowed))
+	if _, err := g.Braid(1, 1); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("g.Braid(1, 1) WithDiagonals = %v, expected: %v", err, ErrUnsupported)
+	}
+}
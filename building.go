@@ -0,0 +1,307 @@
+package maze
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Building is a maze with several floors on top of each other, where every floor
+// is a Graph of the same size and selected vertices connect the floors with
+// stairs or lifts.
+type Building struct {
+	// floors contains the floors from the bottom up, floor 1 is floors[0].
+	floors []*Graph
+
+	// links contains the stairs and lifts going from every vertex, keyed by
+	// the index of the vertex in the building, see index.
+	links map[int][]link
+}
+
+// link is a stair or lift to the vertex with index to in the building,
+// with the cost of taking it.
+type link struct {
+	to   int
+	cost int
+}
+
+// Location is the coordinate of a vertex on a floor of a Building.
+// The bottom floor is floor 1.
+type Location struct {
+	Floor int
+	Coord
+}
+
+// The method String returns the location on the format "f:(y,x)".
+func (l Location) String() string {
+	return strconv.Itoa(l.Floor) + ":" + l.Coord.String()
+}
+
+// NewBuilding creates a building with the specified number of floors, where every
+// floor is a graph of size, width x height, created by New with the options.
+//
+// NewBuilding returns an error wrapping ErrInvalidSize if floors, width or height <= 0.
+func NewBuilding(floors int, height int, width int, options ...Option) (*Building, error) {
+	if floors <= 0 {
+		return nil, fmt.Errorf("%w: %d floors", ErrInvalidSize, floors)
+	}
+	b := &Building{links: make(map[int][]link)}
+	for f := 0; f < floors; f++ {
+		g, err := New(height, width, options...)
+		if err != nil {
+			return nil, err
+		}
+		b.floors = append(b.floors, g)
+	}
+	return b, nil
+}
+
+// The method Floor returns the graph of floor n, which can be changed to add
+// obstacles, walls and costs to the floor. It returns nil if there's no such floor.
+func (b *Building) Floor(n int) *Graph {
+	if n < 1 || n > len(b.floors) {
+		return nil
+	}
+	return b.floors[n-1]
+}
+
+// The method AddStairs connects two vertices on different floors in both directions,
+// with the cost of taking the stairs or lift between them. The vertices don't have
+// to be above each other, and a lift can skip floors. Adding stairs that already exist,
+// in either direction, changes their cost.
+//
+// AddStairs returns an error if a location is outside of the building, is an obstacle,
+// if the locations are on the same floor or if cost < 1.
+func (b *Building) AddStairs(from Location, to Location, cost int) error {
+	i, err := b.index(from)
+	if err != nil {
+		return err
+	}
+	j, err := b.index(to)
+	if err != nil {
+		return err
+	}
+	if from.Floor == to.Floor {
+		return fmt.Errorf("%w: %v and %v are on the same floor", ErrNotAdjacent, from, to)
+	}
+	if cost < 1 {
+		return fmt.Errorf("%w: stairs from %v to %v have cost %d", ErrInvalidCost, from, to, cost)
+	}
+	for _, l := range []Location{from, to} {
+		if g := b.floors[l.Floor-1]; g.cells[g.index(l.Coord)]&obstacleFlag != 0 {
+			return fmt.Errorf("%w: %v", ErrIsObstacle, l)
+		}
+	}
+	for _, e := range [][2]int{{i, j}, {j, i}} {
+		b.setLink(e[0], e[1], cost)
+	}
+	return nil
+}
+
+// setLink adds a link from the vertex i to the vertex j with the cost, or changes the
+// cost of the link if it already exists.
+func (b *Building) setLink(i int, j int, cost int) {
+	for k, l := range b.links[i] {
+		if l.to == j {
+			b.links[i][k].cost = cost
+			return
+		}
+	}
+	b.links[i] = append(b.links[i], link{j, cost})
+}
+
+// The method SetStart marks the specified location as the only "startVertex" of the
+// building, see Graph.SetStart.
+func (b *Building) SetStart(l Location) error {
+	if _, err := b.index(l); err != nil {
+		return err
+	}
+	g := b.floors[l.Floor-1]
+	if err := g.SetStart(l.Coord); err != nil {
+		return err
+	}
+	for _, other := range b.floors {
+		if other != g {
+			for _, i := range other.starts {
+				other.cells[i] &^= startFlag
+			}
+			other.starts = other.starts[:0]
+		}
+	}
+	return nil
+}
+
+// The method SetFinish marks the specified location as the only "finishVertex" of the
+// building, see Graph.SetFinish.
+func (b *Building) SetFinish(l Location) error {
+	if _, err := b.index(l); err != nil {
+		return err
+	}
+	g := b.floors[l.Floor-1]
+	if err := g.SetFinish(l.Coord); err != nil {
+		return err
+	}
+	for _, other := range b.floors {
+		if other != g {
+			for _, i := range other.finishes {
+				other.cells[i] &^= finishFlag
+			}
+			other.finishes = other.finishes[:0]
+		}
+	}
+	return nil
+}
+
+// index returns the index of the location in the building, i.e. the index
+// of the vertex on its floor plus the number of vertices on the floors below.
+func (b *Building) index(l Location) (int, error) {
+	if l.Floor < 1 || l.Floor > len(b.floors) {
+		return 0, fmt.Errorf("%w: %v", ErrOutOfBounds, l)
+	}
+	g := b.floors[l.Floor-1]
+	if err := g.checkBounds(l.Coord); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrOutOfBounds, l)
+	}
+	return (l.Floor-1)*len(g.cells) + g.index(l.Coord), nil
+}
+
+// location returns the location of the vertex with index i in the building.
+func (b *Building) location(i int) Location {
+	n := len(b.floors[0].cells)
+	return Location{i/n + 1, b.floors[0].coord(i % n)}
+}
+
+// The method CheapestPath returns the total cost of the cheapest path from a startVertex
+// to a finishVertex on any floor, and the locations on the path.
+//
+// Moving on a floor costs the same as in Graph.CheapestPath and taking stairs
+// costs the cost given to AddStairs.
+//
+// CheapestPath returns an error wrapping ErrNoPath if there's no start- or finishVertex
+// or if there's no path between them.
+func (b *Building) CheapestPath() (int, []Location, error) {
+	n := len(b.floors[0].cells)
+	predecessor := make([]int32, n*len(b.floors))
+	cost := make([]int, n*len(b.floors))
+	for i := range predecessor {
+		predecessor[i] = -1
+		cost[i] = math.MaxInt
+	}
+	queue := &costHeap{}
+	finishes := 0
+	for f, g := range b.floors {
+		for _, i := range g.starts {
+			predecessor[f*n+i] = int32(f*n + i)
+			cost[f*n+i] = 0
+			*queue = append(*queue, costItem{index: f*n + i})
+		}
+		finishes += len(g.finishes)
+	}
+	if queue.Len() == 0 || finishes == 0 {
+		return 0, nil, fmt.Errorf("%w: start- or finishVertex not set", ErrNoPath)
+	}
+
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(costItem)
+		a := item.index
+		if item.cost > cost[a] {
+			continue
+		}
+		g, i := b.floors[a/n], a%n
+		if g.cells[i]&finishFlag != 0 {
+			var path []Location
+			for ; int(predecessor[a]) != a; a = int(predecessor[a]) {
+				path = append(path, b.location(a))
+			}
+			path = append(path, b.location(a))
+			for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+				path[l], path[r] = path[r], path[l]
+			}
+			return item.cost, path, nil
+		}
+		relax := func(x int, c int) {
+			if c < cost[x] {
+				cost[x] = c
+				predecessor[x] = int32(a)
+				heap.Push(queue, costItem{index: x, cost: c, priority: float64(c)})
+			}
+		}
+		for d := range directions {
+			if g.cells[i]&passage(d) != 0 {
+				relax(a-i+g.step(i, d), cost[a]+g.cost(g.step(i, d)))
+			}
+		}
+		for _, p := range g.portals[i] {
+			if g.cells[p.to]&obstacleFlag == 0 {
+				relax(a-i+p.to, cost[a]+p.cost)
+			}
+		}
+		for _, l := range b.links[a] {
+			if b.floors[l.to/n].cells[l.to%n]&obstacleFlag == 0 {
+				relax(l.to, cost[a]+l.cost)
+			}
+		}
+	}
+	return 0, nil, ErrNoPath
+}
+
+// The method String returns the ASCII representation of every floor from the bottom up,
+// like Graph.String, with a "floor n:" line before every floor.
+//
+// The stairs and lifts are displayed as: ( ^ ) going up, ( v ) going down
+// and (^v ) going both up and down.
+func (b *Building) String() string {
+	return b.render(nil)
+}
+
+// The method StringCheapestPath returns the ASCII representation of every floor,
+// like String, with the path returned by CheapestPath displayed as ( p ) and the
+// cost of the path, like Graph.StringCheapestPath.
+func (b *Building) StringCheapestPath() string {
+	cost, path, err := b.CheapestPath()
+	result := "\n" + b.render(path)
+	if err != nil {
+		return result + "\n" + err.Error()
+	}
+	return result + "\n" + "cost =" + strconv.Itoa(cost)
+}
+
+// render returns the floors with the stairs and the path marked.
+func (b *Building) render(path []Location) string {
+	n := len(b.floors[0].cells)
+	var sb strings.Builder
+	for f, g := range b.floors {
+		marks := make(map[int]string)
+		var floorPath []Coord
+		for _, l := range path {
+			if l.Floor == f+1 {
+				marks[g.index(l.Coord)] = "p"
+				floorPath = append(floorPath, l.Coord)
+			}
+		}
+		for i := 0; i < n; i++ {
+			up, down := false, false
+			for _, l := range b.links[f*n+i] {
+				up = up || l.to/n > f
+				down = down || l.to/n < f
+			}
+			// The "p" of the path is kept in front of the stairs.
+			switch {
+			case up && down:
+				marks[i] += "^v"
+			case up:
+				marks[i] += "^"
+			case down:
+				marks[i] += "v"
+			}
+		}
+		if f > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("floor " + strconv.Itoa(f+1) + ":\n")
+		sb.WriteString(g.render(marks, floorPath))
+	}
+	return sb.String()
+}
@@ -0,0 +1,89 @@
+package maze
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewBuilding(t *testing.T) {
+	if _, err := NewBuilding(0, 3, 3); !errors.Is(err, ErrInvalidSize) {
+		t.Errorf("NewBuilding(0, 3, 3) error = %v, expected: %v", err, ErrInvalidSize)
+	}
+	if _, err := NewBuilding(2, 3, 0); !errors.Is(err, ErrInvalidSize) {
+		t.Errorf("NewBuilding(2, 3, 0) error = %v, expected: %v", err, ErrInvalidSize)
+	}
+	b, _ := NewBuilding(3, 2, 2)
+	if b.Floor(1) == nil || b.Floor(3) == nil || b.Floor(0) != nil || b.Floor(4) != nil {
+		t.Errorf("Error: Building.Floor not working properly.")
+	}
+
+	b.Floor(2).AddObstacle(1, 1)
+	var tests = []struct {
+		from, to Location
+		cost     int
+		exp      error
+	}{
+		{Location{1, Coord{1, 1}}, Location{3, Coord{1, 1}}, 5, nil},
+		{Location{1, Coord{1, 1}}, Location{1, Coord{2, 2}}, 1, ErrNotAdjacent},
+		{Location{1, Coord{1, 1}}, Location{4, Coord{1, 1}}, 1, ErrOutOfBounds},
+		{Location{1, Coord{3, 1}}, Location{2, Coord{1, 1}}, 1, ErrOutOfBounds},
+		{Location{1, Coord{1, 2}}, Location{2, Coord{1, 2}}, 0, ErrInvalidCost},
+		{Location{1, Coord{1, 1}}, Location{2, Coord{1, 1}}, 1, ErrIsObstacle},
+	}
+	for _, e := range tests {
+		if err := b.AddStairs(e.from, e.to, e.cost); !errors.Is(err, e.exp) {
+			t.Errorf("b.AddStairs(%v, %v, %v) = %v, expected: %v", e.from, e.to, e.cost, err, e.exp)
+		}
+	}
+
+	// Adding the same stairs again, in either direction, changes their cost.
+	b.AddStairs(Location{3, Coord{1, 1}}, Location{1, Coord{1, 1}}, 2)
+	i, _ := b.index(Location{1, Coord{1, 1}})
+	j, _ := b.index(Location{3, Coord{1, 1}})
+	if len(b.links[i]) != 1 || len(b.links[j]) != 1 || b.links[i][0] != (link{j, 2}) || b.links[j][0] != (link{i, 2}) {
+		t.Errorf("b.links = %v, expected one link with cost 2 in each direction", b.links)
+	}
+}
+
+func TestBuildingCheapestPath(t *testing.T) {
+	b, _ := NewBuilding(2, 3, 3)
+	b.SetStart(Location{1, Coord{1, 1}})
+	b.SetFinish(Location{2, Coord{1, 1}})
+	if _, _, err := b.CheapestPath(); !errors.Is(err, ErrNoPath) {
+		t.Errorf("b.CheapestPath() error = %v, expected: %v", err, ErrNoPath)
+	}
+
+	b.AddStairs(Location{1, Coord{3, 3}}, Location{2, Coord{3, 3}}, 2)
+	cost, path, err := b.CheapestPath()
+	if err != nil || cost != 10 || len(path) != 10 || path[4] != (Location{1, Coord{3, 3}}) || path[5] != (Location{2, Coord{3, 3}}) {
+		t.Errorf("b.CheapestPath() = %v, %v, %v; expected: 10, a path taking the stairs at (3,3)", cost, path, err)
+	}
+
+	b.AddStairs(Location{1, Coord{1, 3}}, Location{2, Coord{3, 1}}, 1)
+	b.Floor(2).SetCost(Coord{2, 1}, 5)
+	if cost, _, _ := b.CheapestPath(); cost != 7 {
+		t.Errorf("b.CheapestPath() cost = %v, expected: 7", cost)
+	}
+
+	b.SetStart(Location{2, Coord{2, 2}})
+	if len(b.Floor(1).Starts()) != 0 {
+		t.Errorf("Error: Building.SetStart kept the startVertex on floor 1.")
+	}
+	if cost, path, _ := b.CheapestPath(); cost != 2 || path[0].Floor != 2 {
+		t.Errorf("b.CheapestPath() = %v, %v; expected: 2 on floor 2", cost, path)
+	}
+
+	route, _ := NewBuilding(2, 3, 3)
+	route.SetStart(Location{1, Coord{1, 1}})
+	route.SetFinish(Location{2, Coord{1, 1}})
+	route.AddStairs(Location{1, Coord{3, 3}}, Location{2, Coord{3, 3}}, 1)
+	if res := route.StringCheapestPath(); strings.Count(res, "(p^ )") != 1 || strings.Count(res, "(pv )") != 1 {
+		t.Errorf("route.StringCheapestPath() = %v, expected the stairs at (3,3) on the path", res)
+	}
+
+	res := b.StringCheapestPath()
+	if !strings.Contains(res, "floor 1:\n") || !strings.Contains(res, "floor 2:\n") || strings.Count(res, "( ^ )") != 2 || strings.Count(res, "( v )") != 2 || !strings.HasSuffix(res, "cost =2") {
+		t.Errorf("b.StringCheapestPath() = %v, expected both floors with the stairs marked", res)
+	}
+}
@@ -0,0 +1,224 @@
+package maze
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Cave contains the parameters of GenerateCave. The cellular automaton counts the
+// obstacles among the 8 vertices around every vertex, where the vertices outside of
+// the graph count as obstacles.
+type Cave struct {
+	// Density is the fraction of the vertices, 0-1, that start as obstacles.
+	Density float64
+
+	// Birth is the number of obstacles around an open vertex, 0-8, that turns it
+	// into an obstacle, and Survival the number around an obstacle that keeps it.
+	Birth    int
+	Survival int
+
+	// Passes is the number of smoothing passes of the cellular automaton.
+	Passes int
+
+	// Connect decides what happens to the open regions that can't be reached from
+	// each other. If true they're connected with tunnels, otherwise every region
+	// except the largest one is filled with obstacles.
+	Connect bool
+}
+
+// DefaultCave is the parameters of a typical cave, with the 4-5 rule.
+var DefaultCave = Cave{Density: 0.45, Birth: 5, Survival: 4, Passes: 4}
+
+// The method GenerateCave turns the graph into a cave with a cellular automaton.
+// The vertices start as random obstacles with the density of the cave, and every
+// pass turns the vertices with enough obstacles around them into obstacles and
+// the rest into open vertices, which smooths the noise into organic caves.
+//
+// The obstacles work like the ones added with AddObstacle, so every path finder
+// follows the cave. Every open vertex can be reached from the others after the
+// regions are connected or filled, see Cave. The start- and finishVertex are
+// placed far apart, at the ends of the longest shortest path that two BFS
+// sweeps find.
+//
+// GenerateCave removes every obstacle, wall, one-way passage, start- and finishVertex
+// of the graph first. The same cave and seed always give the same cave for a graph
+// of the same size. It returns an error wrapping ErrInvalidArgument if a parameter is
+// outside of its range, an error wrapping ErrUnsupported for hex grids and an error
+// wrapping ErrNoPath if the cave ends up with fewer than two open vertices.
+func (g *Graph) GenerateCave(cave Cave, seed int64) error {
+	if g.hex {
+		return fmt.Errorf("%w: caves can't be generated on hex grids", ErrUnsupported)
+	}
+	if cave.Density < 0 || cave.Density > 1 || cave.Birth < 0 || cave.Birth > 8 ||
+		cave.Survival < 0 || cave.Survival > 8 || cave.Passes < 0 {
+		return fmt.Errorf("%w: %+v", ErrInvalidArgument, cave)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	g.starts, g.finishes, g.rooms = g.starts[:0], g.finishes[:0], nil
+	for i := range g.cells {
+		g.cells[i] = 0
+		if rng.Float64() < cave.Density {
+			g.cells[i] = obstacleFlag
+		}
+	}
+
+	next := make([]cell, len(g.cells))
+	for pass := 0; pass < cave.Passes; pass++ {
+		for i := range g.cells {
+			around := 0
+			for d := range directions {
+				if j, ok := g.adjacent(i, d); !ok || g.cells[j]&obstacleFlag != 0 {
+					around++
+				}
+			}
+			next[i] = 0
+			obstacle := g.cells[i]&obstacleFlag != 0
+			if obstacle && around >= cave.Survival || !obstacle && around >= cave.Birth {
+				next[i] = obstacleFlag
+			}
+		}
+		copy(g.cells, next)
+	}
+	g.refreshAll()
+
+	regions := g.regions()
+	if len(regions) == 0 {
+		return fmt.Errorf("%w: the cave has no open vertices", ErrNoPath)
+	}
+	if cave.Connect {
+		g.connectRegions(regions)
+	} else {
+		for _, region := range regions[1:] {
+			for _, i := range region {
+				g.cells[i] |= obstacleFlag
+			}
+		}
+	}
+	g.refreshAll()
+	return g.placeFarApart(regions[0][0])
+}
+
+// regions returns the open vertices in every region of vertices that can be
+// reached from each other, with the largest region first.
+func (g *Graph) regions() [][]int {
+	seen := make([]bool, len(g.cells))
+	var regions [][]int
+	largest := 0
+	for i := range g.cells {
+		if seen[i] || g.cells[i]&obstacleFlag != 0 {
+			continue
+		}
+		seen[i] = true
+		region := []int{i}
+		for head := 0; head < len(region); head++ {
+			for k, n := 0, g.edges(region[head]); k < n; k++ {
+				if x, _, ok := g.successor(region[head], k); ok && !seen[x] {
+					seen[x] = true
+					region = append(region, x)
+				}
+			}
+		}
+		if len(regions) > 0 && len(region) > len(regions[largest]) {
+			largest = len(regions)
+		}
+		regions = append(regions, region)
+	}
+	if len(regions) > 0 {
+		regions[0], regions[largest] = regions[largest], regions[0]
+	}
+	return regions
+}
+
+// connectRegions connects every region to the first one with short tunnels. A BFS over
+// every vertex, obstacles included, starts from the first region and follows the graph's
+// own adjacency, so the tunnels also go across the edges of a graph that wraps. When it
+// reaches a region that isn't connected yet, the obstacles on the way back are removed
+// and the vertices of the region are added to the BFS, so every vertex is visited once.
+// The passages aren't updated.
+func (g *Graph) connectRegions(regions [][]int) {
+	// region contains the index of the region of every open vertex plus one, and 0
+	// for the obstacles.
+	region := make([]int, len(g.cells))
+	for k, r := range regions {
+		for _, i := range r {
+			region[i] = k + 1
+		}
+	}
+	predecessor := make([]int, len(g.cells))
+	for i := range predecessor {
+		predecessor[i] = -1
+	}
+	queue := make([]int, 0, len(g.cells))
+	join := func(k int) {
+		for _, i := range regions[k] {
+			predecessor[i] = i
+			queue = append(queue, i)
+		}
+	}
+	join(0)
+	for head := 0; head < len(queue); head++ {
+		a := queue[head]
+		for d := 0; d < 4; d++ {
+			x, ok := g.adjacent(a, d)
+			if !ok || predecessor[x] >= 0 {
+				continue
+			}
+			predecessor[x] = a
+			if region[x] == 0 {
+				queue = append(queue, x)
+				continue
+			}
+			for i := a; predecessor[i] != i; i = predecessor[i] {
+				g.cells[i] &^= obstacleFlag
+			}
+			join(region[x] - 1)
+		}
+	}
+}
+
+// tunnel removes the obstacles on the way from the vertex a to the vertex b, first
+// along the column and then along the row. The passages aren't updated, and the way
+// doesn't wrap around.
+func (g *Graph) tunnel(a Coord, b Coord) {
+	for c := a; ; {
+		g.cells[g.index(c)] &^= obstacleFlag
+		if c == b {
+			return
+		}
+		if c.Row != b.Row {
+			c.Row += sign(b.Row - c.Row)
+		} else {
+			c.Col += sign(b.Col - c.Col)
+		}
+	}
+}
+
+// sign returns -1, 0 or 1 for a negative, zero or positive x.
+func sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	}
+	return 0
+}
+
+// placeFarApart makes the vertex farthest from the vertex from the only startVertex and
+// the vertex farthest from the startVertex the only finishVertex, which are the ends
+// of a long shortest path. It returns an error wrapping ErrNoPath if no other vertex
+// can be reached from the vertex from. The graph must not have any finishVertices.
+func (g *Graph) placeFarApart(from int) error {
+	_, _, order, _ := g.search([]int{from}, -1, false)
+	if len(order) < 2 {
+		return fmt.Errorf("%w: fewer than two open vertices", ErrNoPath)
+	}
+	start := int(order[len(order)-1])
+	_, _, order, _ = g.search([]int{start}, -1, false)
+	finish := int(order[len(order)-1])
+	g.cells[start] |= startFlag
+	g.starts = append(g.starts[:0], start)
+	g.cells[finish] |= finishFlag
+	g.finishes = append(g.finishes[:0], finish)
+	return nil
+}
@@ -0,0 +1,99 @@
+package maze
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// isConnected reports whether every open vertex of the graph can be reached
+// from the others.
+func isConnected(g *Graph) bool {
+	return len(g.regions()) == 1
+}
+
+func TestGenerateCave(t *testing.T) {
+	open := make(map[bool]int)
+	for _, connect := range []bool{false, true} {
+		cave := Cave{Density: 0.5, Birth: 5, Survival: 4, Passes: 3}
+		cave.Connect = connect
+		g, _ := New(30, 40)
+		if err := g.GenerateCave(cave, 3); err != nil {
+			t.Fatalf("g.GenerateCave(%+v, 3) = %v, expected: <nil>", cave, err)
+		}
+		if !isConnected(g) {
+			t.Errorf("g.GenerateCave(%+v, 3) has unreachable open vertices:\n%v", cave, g)
+		}
+		distance, _, err := g.FastestPath()
+		if err != nil || distance < 20 {
+			t.Errorf("g.FastestPath() = %v, %v; expected the start- and finishVertex far apart", distance, err)
+		}
+		for i := range g.cells {
+			if g.cells[i]&obstacleFlag == 0 {
+				open[connect]++
+			}
+		}
+
+		h, _ := New(30, 40)
+		h.GenerateCave(cave, 3)
+		if g.String() != h.String() {
+			t.Errorf("g.GenerateCave(%+v, 3) gave different caves for the same seed", cave)
+		}
+	}
+	if open[true] <= open[false] {
+		t.Errorf("connected cave has %v open vertices, expected more than the %v of the largest region", open[true], open[false])
+	}
+
+	// An obstacle is only kept by Survival, even when it has Birth obstacles around it.
+	caves := make(map[int]string)
+	for _, survival := range []int{3, 8} {
+		g, _ := New(20, 20)
+		cave := Cave{Density: 0.5, Birth: 3, Survival: survival, Passes: 1, Connect: true}
+		g.GenerateCave(cave, 2)
+		caves[survival] = g.String()
+	}
+	if caves[3] == caves[8] {
+		t.Errorf("g.GenerateCave() with Birth 3 gave the same cave for Survival 3 and 8")
+	}
+
+	g, _ := New(10, 10)
+	var tests = []struct {
+		cave Cave
+		exp  error
+	}{
+		{Cave{Density: 1.5}, ErrInvalidArgument},
+		{Cave{Density: 0.5, Birth: 9}, ErrInvalidArgument},
+		{Cave{Density: 0.5, Passes: -1}, ErrInvalidArgument},
+		{Cave{Density: 1}, ErrNoPath},
+		{Cave{Density: 0}, nil},
+	}
+	for _, e := range tests {
+		if err := g.GenerateCave(e.cave, 1); !errors.Is(err, e.exp) {
+			t.Errorf("g.GenerateCave(%+v, 1) = %v, expected: %v", e.cave, err, e.exp)
+		}
+	}
+	g, _ = New(3, 3, WithHex())
+	if err := g.GenerateCave(DefaultCave, 1); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("g.GenerateCave(DefaultCave, 1) WithHex = %v, expected: %v", err, ErrUnsupported)
+	}
+}
+
+func TestGenerateCaveLarge(t *testing.T) {
+	// Without smoothing the noise has tens of thousands of regions to connect.
+	g, _ := New(500, 500)
+	start := time.Now()
+	if err := g.GenerateCave(Cave{Density: 0.45, Birth: 5, Survival: 4, Connect: true}, 1); err != nil {
+		t.Fatalf("g.GenerateCave() error = %v, expected: <nil>", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("g.GenerateCave() on 500x500 took %v", elapsed)
+	}
+	if !isConnected(g) {
+		t.Errorf("g.GenerateCave() on 500x500 has unreachable open vertices")
+	}
+
+	torus, _ := New(20, 30, WithWrap(WrapBoth))
+	if err := torus.GenerateCave(Cave{Density: 0.6, Birth: 5, Survival: 4, Passes: 2, Connect: true}, 4); err != nil || !isConnected(torus) {
+		t.Errorf("torus.GenerateCave() = %v, expected a connected cave:\n%v", err, torus)
+	}
+}
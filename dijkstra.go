@@ -0,0 +1,164 @@
+package maze
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+)
+
+// The method SetCost sets the cost of moving into the specified vertex. Every vertex
+// has the cost 1 until it's set, so terrain like mud or water can be represented
+// by giving its vertices a higher cost.
+//
+// SetCost returns an error if the vertex is outside of the graph or if cost < 1.
+func (g *Graph) SetCost(c Coord, cost int) error {
+	if err := g.checkBounds(c); err != nil {
+		return err
+	}
+	if cost < 1 || cost > math.MaxInt32 {
+		return fmt.Errorf("%w: %v has cost %d", ErrInvalidCost, c, cost)
+	}
+	if g.costs == nil {
+		if cost == 1 {
+			return nil
+		}
+		g.costs = make([]int32, len(g.cells))
+		for i := range g.costs {
+			g.costs[i] = 1
+		}
+	}
+	g.costs[g.index(c)] = int32(cost)
+	return nil
+}
+
+// The method Cost returns the cost of moving into the specified vertex,
+// or 0 if the vertex is outside of the graph.
+func (g *Graph) Cost(c Coord) int {
+	if g.checkBounds(c) != nil {
+		return 0
+	}
+	return g.cost(g.index(c))
+}
+
+// cost returns the cost of moving into the vertex at index i.
+func (g *Graph) cost(i int) int {
+	if g.costs == nil {
+		return 1
+	}
+	return int(g.costs[i])
+}
+
+// The method StringCheapestPath returns a ASCII representation of the cheapest
+// path between the start- and finishvertex and the cost of the path.
+//
+// The representation is the same as for StringFastestPath, but ends with
+// "cost =" instead of "distance =".
+func (g *Graph) StringCheapestPath() string {
+	cost, path, err := g.CheapestPath()
+	return g.stringPath(path, "cost =", cost, err)
+}
+
+// The method CheapestPath returns the total cost of the cheapest path between the start-
+// and finishvertex and a slice of the coordinates on the path.
+//
+// The cost of a path is the sum of the costs of every vertex on the path
+// except the startVertex, see SetCost, and taking a portal costs the cost given to
+// AddPortal instead of the cost of the vertex it leads to. When no costs are set CheapestPath
+// returns the same distance as FastestPath. With several start- or finishVertices
+// the path is the cheapest one from any startVertex to any finishVertex.
+//
+// CheapestPath returns an error wrapping ErrNoPath if the start- or finishVertex
+// isn't set or if there's no path between them.
+func (g *Graph) CheapestPath() (int, []Coord, error) {
+	if err := g.checkEndpoints(); err != nil {
+		return 0, nil, err
+	}
+	predecessor, finish, cost := g.cheapestPathDijkstra()
+	if finish < 0 {
+		return 0, nil, ErrNoPath
+	}
+	return cost, g.tracePath(predecessor, finish), nil
+}
+
+// cheapestPathDijkstra visits the vertices in order of increasing cost from the startVertices
+// using a binary heap, until a finishVertex is reached. It returns the predecessor
+// of every visited vertex, the index of the reached finishVertex or -1 if none was
+// reached, and its cost.
+func (g *Graph) cheapestPathDijkstra() ([]int32, int, int) {
+	predecessor := make([]int32, len(g.cells))
+	cost := make([]int, len(g.cells))
+	for i := range predecessor {
+		predecessor[i] = -1
+		cost[i] = math.MaxInt
+	}
+
+	queue := &costHeap{}
+	for _, i := range g.starts {
+		predecessor[i] = int32(i)
+		cost[i] = 0
+		*queue = append(*queue, costItem{index: i})
+	}
+
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(costItem)
+		a := item.index
+		if item.cost > cost[a] {
+			continue
+		}
+		if g.cells[a]&finishFlag != 0 {
+			return predecessor, a, cost[a]
+		}
+		for d := range directions {
+			if g.cells[a]&passage(d) == 0 {
+				continue
+			}
+			x := g.step(a, d)
+			if c := cost[a] + g.cost(x); c < cost[x] {
+				cost[x] = c
+				predecessor[x] = int32(a)
+				heap.Push(queue, costItem{index: x, cost: c, priority: float64(c)})
+			}
+		}
+		for _, p := range g.portals[a] {
+			x := p.to
+			if c := cost[a] + p.cost; c < cost[x] && g.cells[x]&obstacleFlag == 0 {
+				cost[x] = c
+				predecessor[x] = int32(a)
+				heap.Push(queue, costItem{index: x, cost: c, priority: float64(c)})
+			}
+		}
+	}
+	return predecessor, -1, 0
+}
+
+// costItem is a vertex in a costHeap, with the cost of the cheapest known path
+// to it and its priority in the heap.
+type costItem struct {
+	index    int
+	cost     int
+	priority float64
+}
+
+// costHeap is a binary min-heap of costItems ordered by priority, where ties
+// are broken by the highest cost. It implements heap.Interface.
+type costHeap []costItem
+
+func (h costHeap) Len() int { return len(h) }
+
+func (h costHeap) Less(i, j int) bool {
+	if h[i].priority == h[j].priority {
+		return h[i].cost > h[j].cost
+	}
+	return h[i].priority < h[j].priority
+}
+
+func (h costHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *costHeap) Push(x any) { *h = append(*h, x.(costItem)) }
+
+func (h *costHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
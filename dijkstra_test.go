@@ -0,0 +1,63 @@
+package maze
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSetCost(t *testing.T) {
+	g := NewGraph(2, 2)
+	if g.Cost(Coord{1, 1}) != 1 || g.costs != nil {
+		t.Errorf("Error: the default cost isn't 1.")
+	}
+	g.SetCost(Coord{2, 2}, 7)
+	if g.Cost(Coord{2, 2}) != 7 || g.Cost(Coord{1, 2}) != 1 || g.Cost(Coord{3, 1}) != 0 {
+		t.Errorf("Error: SetCost not working properly, g.Cost.")
+	}
+
+	var tests = []struct {
+		c    Coord
+		cost int
+		exp  error
+	}{
+		{Coord{1, 1}, 1, nil},
+		{Coord{1, 1}, 0, ErrInvalidCost},
+		{Coord{1, 1}, -4, ErrInvalidCost},
+		{Coord{3, 1}, 2, ErrOutOfBounds},
+	}
+	for _, e := range tests {
+		if err := g.SetCost(e.c, e.cost); !errors.Is(err, e.exp) {
+			t.Errorf("g.SetCost(%v, %v) = %v, expected: %v", e.c, e.cost, err, e.exp)
+		}
+	}
+}
+
+func TestCheapestPath(t *testing.T) {
+	g := NewGraph(3, 3)
+	g.AddStart(1, 1)
+	g.AddFinish(1, 3)
+
+	cost, path, err := g.CheapestPath()
+	dist, _ := g.GetFastestPath()
+	if err != nil || cost != dist || len(path) != 3 {
+		t.Errorf("g.CheapestPath() = %v, %v, %v; expected: %v, [(1,1) (1,2) (1,3)], <nil>", cost, path, err, dist)
+	}
+
+	g.SetCost(Coord{1, 2}, 10) // mud
+	g.SetCost(Coord{2, 2}, 2)  // water
+	exp := []Coord{{1, 1}, {2, 1}, {2, 2}, {2, 3}, {1, 3}}
+	cost, path, err = g.CheapestPath()
+	if err != nil || cost != 5 || !coordSliceEq(path, exp) {
+		t.Errorf("g.CheapestPath() = %v, %v, %v; expected: %v, %v, <nil>", cost, path, err, 5, exp)
+	}
+	if res := g.StringCheapestPath(); !strings.HasSuffix(res, "\ncost =5") || strings.Count(res, "( p )") != 3 {
+		t.Errorf("g.StringCheapestPath() = %v, expected the path and cost =5", res)
+	}
+
+	g.AddObstacle(2, 3)
+	g.AddObstacle(1, 2)
+	if _, _, err := g.CheapestPath(); !errors.Is(err, ErrNoPath) {
+		t.Errorf("g.CheapestPath() error = %v, expected: %v", err, ErrNoPath)
+	}
+}
@@ -0,0 +1,177 @@
+package maze
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Room is a rectangular room placed by GenerateDungeon, from the vertex Min in the
+// top left corner to the vertex Max in the bottom right corner.
+type Room struct {
+	Min Coord
+	Max Coord
+}
+
+// The method Contains reports whether the vertex c is in the room.
+func (r Room) Contains(c Coord) bool {
+	return r.Min.Row <= c.Row && c.Row <= r.Max.Row && r.Min.Col <= c.Col && c.Col <= r.Max.Col
+}
+
+// The method Center returns the vertex in the middle of the room, rounded up and to the left.
+func (r Room) Center() Coord {
+	return Coord{(r.Min.Row + r.Max.Row) / 2, (r.Min.Col + r.Max.Col) / 2}
+}
+
+// Dungeon contains the parameters of GenerateDungeon.
+type Dungeon struct {
+	// Rooms is the number of rooms to place, fewer are placed if they don't fit.
+	Rooms int
+
+	// MinSize and MaxSize is the smallest and largest height and width of a room.
+	MinSize int
+	MaxSize int
+
+	// Loops is the number of corridors added after the rooms are connected, which
+	// make loops so there's more than one way between some rooms.
+	Loops int
+}
+
+// DefaultDungeon is the parameters of a typical dungeon.
+var DefaultDungeon = Dungeon{Rooms: 8, MinSize: 3, MaxSize: 7, Loops: 2}
+
+// The method GenerateDungeon turns the graph into a dungeon of rooms and corridors,
+// where everything else is obstacles, and returns the rooms. The rooms are placed at
+// random positions where they don't overlap or touch each other, and are joined by
+// corridors along a minimum spanning tree of the distances between their centers,
+// plus the Loops shortest corridors that aren't in the tree. The start- and
+// finishVertex are placed at the centers of the two rooms that are farthest apart.
+//
+// The obstacles work like the ones added with AddObstacle, so every path finder
+// follows the dungeon, and the rooms can be read back with Rooms to theme them.
+// GenerateDungeon removes every obstacle, wall, one-way passage, start- and
+// finishVertex of the graph first. The same dungeon and seed always give the
+// same dungeon for a graph of the same size.
+//
+// GenerateDungeon returns an error wrapping ErrInvalidArgument if a parameter is outside
+// of its range, an error wrapping ErrUnsupported for hex grids and an error wrapping
+// ErrInvalidSize if fewer than two rooms fit in the graph.
+func (g *Graph) GenerateDungeon(dungeon Dungeon, seed int64) ([]Room, error) {
+	if g.hex {
+		return nil, fmt.Errorf("%w: dungeons can't be generated on hex grids", ErrUnsupported)
+	}
+	if dungeon.Rooms < 2 || dungeon.MinSize < 1 || dungeon.MaxSize < dungeon.MinSize || dungeon.Loops < 0 {
+		return nil, fmt.Errorf("%w: %+v", ErrInvalidArgument, dungeon)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	g.starts, g.finishes, g.rooms = g.starts[:0], g.finishes[:0], nil
+	for i := range g.cells {
+		g.cells[i] = obstacleFlag
+	}
+
+	var rooms []Room
+	for try := 0; try < 50*dungeon.Rooms && len(rooms) < dungeon.Rooms; try++ {
+		h := dungeon.MinSize + rng.Intn(dungeon.MaxSize-dungeon.MinSize+1)
+		w := dungeon.MinSize + rng.Intn(dungeon.MaxSize-dungeon.MinSize+1)
+		if h > g.height || w > g.width {
+			continue
+		}
+		corner := Coord{1 + rng.Intn(g.height-h+1), 1 + rng.Intn(g.width-w+1)}
+		room := Room{corner, Coord{corner.Row + h - 1, corner.Col + w - 1}}
+		free := true
+		for _, r := range rooms {
+			if room.Min.Row <= r.Max.Row+1 && r.Min.Row <= room.Max.Row+1 &&
+				room.Min.Col <= r.Max.Col+1 && r.Min.Col <= room.Max.Col+1 {
+				free = false
+				break
+			}
+		}
+		if free {
+			rooms = append(rooms, room)
+		}
+	}
+	if len(rooms) < 2 {
+		g.refreshAll()
+		return nil, fmt.Errorf("%w: only %d rooms fit in %dx%d", ErrInvalidSize, len(rooms), g.height, g.width)
+	}
+	for _, r := range rooms {
+		for y := r.Min.Row; y <= r.Max.Row; y++ {
+			for x := r.Min.Col; x <= r.Max.Col; x++ {
+				g.cells[g.index(Coord{y, x})] = 0
+			}
+		}
+	}
+
+	for _, e := range roomCorridors(rooms, dungeon.Loops) {
+		a, b := rooms[e[0]].Center(), rooms[e[1]].Center()
+		if rng.Intn(2) == 0 {
+			a, b = b, a
+		}
+		g.tunnel(a, b)
+	}
+	g.refreshAll()
+
+	// The rooms are connected, so every BFS from a center reaches the other centers.
+	start, finish, farthest := 0, 1, -1
+	for k, r := range rooms {
+		distance, _, _, _ := g.search([]int{g.index(r.Center())}, -1, true)
+		for j := k + 1; j < len(rooms); j++ {
+			if d := int(distance[g.index(rooms[j].Center())]); d > farthest {
+				start, finish, farthest = k, j, d
+			}
+		}
+	}
+	s, f := g.index(rooms[start].Center()), g.index(rooms[finish].Center())
+	g.cells[s] |= startFlag
+	g.starts = append(g.starts, s)
+	g.cells[f] |= finishFlag
+	g.finishes = append(g.finishes, f)
+	g.rooms = rooms
+	return append([]Room(nil), rooms...), nil
+}
+
+// The method Rooms returns the rooms placed by the last GenerateDungeon, or nil if
+// the graph isn't a dungeon.
+func (g *Graph) Rooms() []Room {
+	return append([]Room(nil), g.rooms...)
+}
+
+// roomCorridors returns the pairs of rooms to join with corridors, i.e. the edges of
+// a minimum spanning tree of the distances between the centers of the rooms, found
+// with Prim's algorithm, followed by the loops shortest edges that aren't in the tree.
+func roomCorridors(rooms []Room, loops int) [][2]int {
+	distance := func(a int, b int) int {
+		ca, cb := rooms[a].Center(), rooms[b].Center()
+		return abs(ca.Row-cb.Row) + abs(ca.Col-cb.Col)
+	}
+	inTree := make([]bool, len(rooms))
+	inTree[0] = true
+	used := make(map[[2]int]bool)
+	var corridors [][2]int
+	for len(corridors) < len(rooms)-1 {
+		best := [2]int{-1, -1}
+		for a := range rooms {
+			for b := range rooms {
+				if inTree[a] && !inTree[b] && (best[0] < 0 || distance(a, b) < distance(best[0], best[1])) {
+					best = [2]int{a, b}
+				}
+			}
+		}
+		inTree[best[1]] = true
+		used[[2]int{min(best[0], best[1]), max(best[0], best[1])}] = true
+		corridors = append(corridors, best)
+	}
+
+	var rest [][2]int
+	for a := range rooms {
+		for b := a + 1; b < len(rooms); b++ {
+			if !used[[2]int{a, b}] {
+				rest = append(rest, [2]int{a, b})
+			}
+		}
+	}
+	sort.SliceStable(rest, func(i, j int) bool {
+		return distance(rest[i][0], rest[i][1]) < distance(rest[j][0], rest[j][1])
+	})
+	return append(corridors, rest[:min(loops, len(rest))]...)
+}
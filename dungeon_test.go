@@ -0,0 +1,76 @@
+package maze
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGenerateDungeon(t *testing.T) {
+	g, _ := New(30, 50)
+	rooms, err := g.GenerateDungeon(DefaultDungeon, 5)
+	if err != nil {
+		t.Fatalf("g.GenerateDungeon(DefaultDungeon, 5) error = %v, expected: <nil>", err)
+	}
+	if len(rooms) < 2 || len(rooms) > DefaultDungeon.Rooms {
+		t.Fatalf("g.GenerateDungeon(DefaultDungeon, 5) placed %v rooms", len(rooms))
+	}
+	for k, r := range rooms {
+		for y := r.Min.Row; y <= r.Max.Row; y++ {
+			for x := r.Min.Col; x <= r.Max.Col; x++ {
+				if g.cells[g.index(Coord{y, x})]&obstacleFlag != 0 {
+					t.Errorf("room %v has the obstacle %v", r, Coord{y, x})
+				}
+			}
+		}
+		for j := k + 1; j < len(rooms); j++ {
+			if grown := (Room{Coord{r.Min.Row - 1, r.Min.Col - 1}, Coord{r.Max.Row + 1, r.Max.Col + 1}}); grown.Contains(rooms[j].Min) || grown.Contains(rooms[j].Max) || rooms[j].Contains(r.Min) {
+				t.Errorf("rooms %v and %v overlap or touch", r, rooms[j])
+			}
+		}
+		if size := r.Max.Row - r.Min.Row + 1; size < DefaultDungeon.MinSize || size > DefaultDungeon.MaxSize {
+			t.Errorf("room %v has the height %v", r, size)
+		}
+	}
+	if !isConnected(g) {
+		t.Errorf("g.GenerateDungeon(DefaultDungeon, 5) has unreachable open vertices:\n%v", g)
+	}
+
+	starts, finishes := g.Starts(), g.Finishes()
+	if len(starts) != 1 || len(finishes) != 1 {
+		t.Fatalf("g.GenerateDungeon() placed the startVertices %v and finishVertices %v", starts, finishes)
+	}
+	distance, _, _ := g.FastestPath()
+	for _, a := range rooms {
+		for _, b := range rooms {
+			g.SetStart(a.Center())
+			if b == a || g.SetFinish(b.Center()) != nil {
+				continue
+			}
+			if d, _, _ := g.FastestPath(); d > distance {
+				t.Errorf("rooms %v and %v are %v apart, farther than the %v of the start- and finishVertex", a, b, d, distance)
+			}
+		}
+	}
+	if res := g.Rooms(); len(res) != len(rooms) || res[0] != rooms[0] {
+		t.Errorf("g.Rooms() = %v, expected: %v", res, rooms)
+	}
+	g.Generate(Prim, 1)
+	if res := g.Rooms(); res != nil {
+		t.Errorf("g.Rooms() after Generate = %v, expected: []", res)
+	}
+
+	var tests = []struct {
+		dungeon Dungeon
+		exp     error
+	}{
+		{Dungeon{Rooms: 1, MinSize: 3, MaxSize: 5}, ErrInvalidArgument},
+		{Dungeon{Rooms: 4, MinSize: 5, MaxSize: 3}, ErrInvalidArgument},
+		{Dungeon{Rooms: 4, MinSize: 20, MaxSize: 30}, ErrInvalidSize},
+	}
+	g, _ = New(10, 10)
+	for _, e := range tests {
+		if _, err := g.GenerateDungeon(e.dungeon, 1); !errors.Is(err, e.exp) {
+			t.Errorf("g.GenerateDungeon(%+v, 1) = %v, expected: %v", e.dungeon, err, e.exp)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package maze
+
+import "fmt"
+
+// Route is a path from one of the startVertices to one of the finishVertices.
+type Route struct {
+	// Start and Finish are the start- and finishVertex the route goes between.
+	Start  Coord
+	Finish Coord
+
+	// Distance is the length of the route, i.e. len(Path) - 1 unless the route
+	// takes portals longer than a step, see AddPortal.
+	Distance int
+
+	// Path contains the coordinates on the route from Start to Finish.
+	Path []Coord
+}
+
+// The method AppendStart marks the specified vertex as a "startVertex", like AddStart,
+// but keeps the existing startVertices so the graph can have several of them.
+//
+// AppendStart returns an error if the vertex is outside of the graph, is an obstacle
+// or is a finishVertex. Appending an existing startVertex does nothing.
+func (g *Graph) AppendStart(c Coord) error {
+	if err := g.checkBounds(c); err != nil {
+		return err
+	}
+	i := g.index(c)
+	if g.cells[i]&obstacleFlag != 0 {
+		return fmt.Errorf("%w: %v", ErrIsObstacle, c)
+	}
+	if g.cells[i]&finishFlag != 0 {
+		return fmt.Errorf("%w: %v", ErrIsFinish, c)
+	}
+	if g.cells[i]&startFlag == 0 {
+		g.cells[i] |= startFlag
+		g.starts = append(g.starts, i)
+	}
+	return nil
+}
+
+// The method AppendFinish marks the specified vertex as a "finishVertex", like AddFinish,
+// but keeps the existing finishVertices so the graph can have several of them.
+//
+// AppendFinish returns an error if the vertex is outside of the graph, is an obstacle
+// or is a startVertex. Appending an existing finishVertex does nothing.
+func (g *Graph) AppendFinish(c Coord) error {
+	if err := g.checkBounds(c); err != nil {
+		return err
+	}
+	i := g.index(c)
+	if g.cells[i]&obstacleFlag != 0 {
+		return fmt.Errorf("%w: %v", ErrIsObstacle, c)
+	}
+	if g.cells[i]&startFlag != 0 {
+		return fmt.Errorf("%w: %v", ErrIsStart, c)
+	}
+	if g.cells[i]&finishFlag == 0 {
+		g.cells[i] |= finishFlag
+		g.finishes = append(g.finishes, i)
+	}
+	return nil
+}
+
+// The method Starts returns the coordinates of the startVertices in the order
+// they were added.
+func (g *Graph) Starts() []Coord {
+	return g.coords(g.starts)
+}
+
+// The method Finishes returns the coordinates of the finishVertices in the order
+// they were added.
+func (g *Graph) Finishes() []Coord {
+	return g.coords(g.finishes)
+}
+
+// coords returns the coordinates of the vertices with the indices.
+func (g *Graph) coords(indices []int) []Coord {
+	result := make([]Coord, len(indices))
+	for k, i := range indices {
+		result[k] = g.coord(i)
+	}
+	return result
+}
+
+// The method NearestRoute returns the shortest route from any of the startVertices
+// to the nearest finishVertex, using a BFS that starts from all startVertices at once.
+//
+// NearestRoute returns an error wrapping ErrNoPath if there's no start- or
+// finishVertex or if no finishVertex can be reached.
+func (g *Graph) NearestRoute() (Route, error) {
+	if err := g.checkEndpoints(); err != nil {
+		return Route{}, err
+	}
+	predecessor, finish, distance := g.fastestPathBFS()
+	if finish < 0 {
+		return Route{}, ErrNoPath
+	}
+	path := g.tracePath(predecessor, finish)
+	return Route{
+		Start:    path[0],
+		Finish:   path[len(path)-1],
+		Distance: distance,
+		Path:     path,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package maze
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAppendStart(t *testing.T) {
+	g := NewGraph(3, 3)
+	g.AddStart(1, 1)
+	g.AppendStart(Coord{3, 3})
+	g.AppendStart(Coord{3, 3})
+	g.AppendFinish(Coord{2, 2})
+	exp := []Coord{{1, 1}, {3, 3}}
+	if res := g.Starts(); !coordSliceEq(res, exp) {
+		t.Errorf("g.Starts() = %v, expected: %v", res, exp)
+	}
+
+	var tests = []struct {
+		name string
+		err  error
+		exp  error
+	}{
+		{"AppendStart(2, 2)", g.AppendStart(Coord{2, 2}), ErrIsFinish},
+		{"AppendFinish(1, 1)", g.AppendFinish(Coord{1, 1}), ErrIsStart},
+		{"AppendStart(4, 1)", g.AppendStart(Coord{4, 1}), ErrOutOfBounds},
+		{"AppendFinish(1, 0)", g.AppendFinish(Coord{1, 0}), ErrOutOfBounds},
+	}
+	for _, e := range tests {
+		if !errors.Is(e.err, e.exp) {
+			t.Errorf("g.%v = %v, expected: %v", e.name, e.err, e.exp)
+		}
+	}
+
+	g.AddObstacle(1, 3)
+	if err := g.AppendFinish(Coord{1, 3}); !errors.Is(err, ErrIsObstacle) {
+		t.Errorf("g.AppendFinish((1,3)) = %v, expected: %v", err, ErrIsObstacle)
+	}
+
+	g.AddStart(2, 1)
+	exp = []Coord{{2, 1}}
+	if res := g.Starts(); !coordSliceEq(res, exp) || g.cells[g.index(Coord{3, 3})]&startFlag != 0 {
+		t.Errorf("g.Starts() = %v, expected: %v", res, exp)
+	}
+}
+
+func TestNearestRoute(t *testing.T) {
+	g := NewGraph(5, 5)
+	g.AddStart(1, 1)
+	g.AppendStart(Coord{5, 1})
+	g.AddFinish(1, 5)
+	g.AppendFinish(Coord{5, 3})
+
+	r, err := g.NearestRoute()
+	if err != nil || r.Start != (Coord{5, 1}) || r.Finish != (Coord{5, 3}) || r.Distance != 2 || len(r.Path) != 3 {
+		t.Errorf("g.NearestRoute() = %+v, %v; expected the route (5,1) to (5,3)", r, err)
+	}
+	if dist, _, _, _ := g.AStar(Manhattan); dist != 2 {
+		t.Errorf("g.AStar(Manhattan) distance = %v, expected: 2", dist)
+	}
+	g.SetCost(Coord{5, 2}, 9)
+	if cost, path, _ := g.CheapestPath(); cost != 4 || path[0] != (Coord{1, 1}) {
+		t.Errorf("g.CheapestPath() = %v, %v; expected: 4, [(1,1) ... (1,5)]", cost, path)
+	}
+
+	res := g.String()
+	if strings.Count(res, "( s )") != 2 || strings.Count(res, "( f )") != 2 {
+		t.Errorf("g.String() = %v, expected two ( s ) and two ( f )", res)
+	}
+
+	g.AddObstacle(4, 1)
+	g.AddObstacle(5, 2)
+	r, err = g.NearestRoute()
+	if err != nil || r.Start != (Coord{1, 1}) || r.Finish != (Coord{1, 5}) || r.Distance != 4 {
+		t.Errorf("g.NearestRoute() = %+v, %v; expected the route (1,1) to (1,5)", r, err)
+	}
+
+	empty := NewGraph(2, 2)
+	if _, err := empty.NearestRoute(); !errors.Is(err, ErrNoPath) {
+		t.Errorf("empty.NearestRoute() error = %v, expected: %v", err, ErrNoPath)
+	}
+}
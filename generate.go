@@ -0,0 +1,335 @@
+package maze
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+)
+
+// Generator is an algorithm that Generate uses to turn a graph into a perfect maze,
+// i.e. a maze with exactly one path between any two vertices.
+type Generator int
+
+const (
+	// RecursiveBacktracker carves a random depth-first walk, which gives long
+	// winding corridors with few dead ends.
+	RecursiveBacktracker Generator = iota
+
+	// Prim grows the maze from a vertex by carving to a random vertex next to
+	// it, which gives many short dead ends.
+	Prim
+
+	// Kruskal removes the walls in random order when they join two separate
+	// parts of the maze.
+	Kruskal
+
+	// Wilson adds loop-erased random walks to the maze, which gives every perfect
+	// maze the same probability.
+	Wilson
+
+	// AldousBroder carves a random walk whenever it enters a new vertex, which gives
+	// every perfect maze the same probability but is slow on large graphs.
+	AldousBroder
+
+	// BinaryTree carves north or east from every vertex, which gives open corridors
+	// along the north and east edges and a diagonal bias.
+	BinaryTree
+
+	// Sidewinder carves runs of vertices to the east and connects every run to
+	// the row above, which gives an open corridor along the north edge.
+	Sidewinder
+
+	// RecursiveDivision starts with an open graph and divides it with walls that
+	// have a single gap, which gives long straight walls.
+	RecursiveDivision
+)
+
+// generatorNames contains the name of every Generator.
+var generatorNames = [...]string{"RecursiveBacktracker", "Prim", "Kruskal", "Wilson", "AldousBroder", "BinaryTree", "Sidewinder", "RecursiveDivision"}
+
+// The method String returns the name of the generator.
+func (gen Generator) String() string {
+	if gen < 0 || int(gen) >= len(generatorNames) {
+		return "Generator(" + strconv.Itoa(int(gen)) + ")"
+	}
+	return generatorNames[gen]
+}
+
+// The method Generate turns the graph into a perfect maze with the generator, i.e.
+// places walls so there's exactly one path between any two vertices. The walls are
+// placed like AddWall, so String displays the maze and every path finder follows it.
+//
+// Generate removes every obstacle, wall and one-way passage of the graph first, but
+// keeps the start- and finishVertices, costs, portals, keys and doors. The same
+// generator and seed always give the same maze for a graph of the same size.
+//
+// Generate returns an error wrapping ErrUnsupported if the graph is created
+// WithDiagonals, WithHex or WithWrap, or if gen isn't one of the generators.
+func (g *Graph) Generate(gen Generator, seed int64) error {
+	if g.dirs != orthogonal || g.hex || g.wrap != 0 {
+		return fmt.Errorf("%w: mazes can only be generated on graphs with orthogonal edges that don't wrap", ErrUnsupported)
+	}
+	if gen < 0 || int(gen) >= len(generatorNames) {
+		return fmt.Errorf("%w: %v", ErrUnsupported, gen)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	g.rooms = nil
+	for i := range g.cells {
+		g.cells[i] &= startFlag | finishFlag
+		if gen == RecursiveDivision {
+			continue
+		}
+		for d := 0; d < 4; d++ {
+			if _, ok := g.adjacent(i, d); ok {
+				g.cells[i] |= wall(d)
+			}
+		}
+	}
+
+	switch gen {
+	case RecursiveBacktracker:
+		g.generateBacktracker(rng)
+	case Prim:
+		g.generatePrim(rng)
+	case Kruskal:
+		g.generateKruskal(rng)
+	case Wilson:
+		g.generateWilson(rng)
+	case AldousBroder:
+		g.generateAldousBroder(rng)
+	case BinaryTree:
+		g.generateBinaryTree(rng)
+	case Sidewinder:
+		g.generateSidewinder(rng)
+	case RecursiveDivision:
+		g.generateDivision(rng)
+	}
+	g.refreshAll()
+	return nil
+}
+
+// refreshAll updates every passage of the graph, see canPass.
+func (g *Graph) refreshAll() {
+	for i := range g.cells {
+		for d := range directions {
+			if g.canPass(i, d) {
+				g.cells[i] |= passage(d)
+			} else {
+				g.cells[i] &^= passage(d)
+			}
+		}
+	}
+}
+
+// carve removes the wall in direction d of the vertex i and returns the index of
+// the adjencent vertex on the other side. The passages are updated by Generate.
+func (g *Graph) carve(i int, d int) int {
+	j, _ := g.adjacent(i, d)
+	g.cells[i] &^= wall(d)
+	g.cells[j] &^= wall(opposite(d))
+	return j
+}
+
+// randomStep returns a random direction from the vertex i that has an adjencent
+// vertex, and the index of that vertex. The graph must have more than one vertex.
+func (g *Graph) randomStep(rng *rand.Rand, i int) (int, int) {
+	for {
+		d := rng.Intn(4)
+		if j, ok := g.adjacent(i, d); ok {
+			return d, j
+		}
+	}
+}
+
+// generateBacktracker is the RecursiveBacktracker generator, with an explicit stack
+// instead of recursion so large graphs don't run out of stack.
+func (g *Graph) generateBacktracker(rng *rand.Rand) {
+	visited := make([]bool, len(g.cells))
+	start := rng.Intn(len(g.cells))
+	visited[start] = true
+	stack := []int{start}
+	dirs := make([]int, 0, 4)
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		dirs = dirs[:0]
+		for d := 0; d < 4; d++ {
+			if j, ok := g.adjacent(i, d); ok && !visited[j] {
+				dirs = append(dirs, d)
+			}
+		}
+		if len(dirs) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		j := g.carve(i, dirs[rng.Intn(len(dirs))])
+		visited[j] = true
+		stack = append(stack, j)
+	}
+}
+
+// generatePrim is the Prim generator, where frontier contains the walls between
+// the maze and the vertices that aren't in it yet, as a vertex and a direction.
+func (g *Graph) generatePrim(rng *rand.Rand) {
+	visited := make([]bool, len(g.cells))
+	var frontier [][2]int
+	add := func(i int) {
+		visited[i] = true
+		for d := 0; d < 4; d++ {
+			if j, ok := g.adjacent(i, d); ok && !visited[j] {
+				frontier = append(frontier, [2]int{i, d})
+			}
+		}
+	}
+	add(rng.Intn(len(g.cells)))
+	for len(frontier) > 0 {
+		k := rng.Intn(len(frontier))
+		e := frontier[k]
+		frontier[k] = frontier[len(frontier)-1]
+		frontier = frontier[:len(frontier)-1]
+		if j, _ := g.adjacent(e[0], e[1]); !visited[j] {
+			add(g.carve(e[0], e[1]))
+		}
+	}
+}
+
+// generateKruskal is the Kruskal generator, which keeps track of the separate parts
+// of the maze with a union-find structure.
+func (g *Graph) generateKruskal(rng *rand.Rand) {
+	parent := make([]int, len(g.cells))
+	for i := range parent {
+		parent[i] = i
+	}
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+	var walls [][2]int
+	for i := range g.cells {
+		for _, d := range []int{1, 2} {
+			if _, ok := g.adjacent(i, d); ok {
+				walls = append(walls, [2]int{i, d})
+			}
+		}
+	}
+	rng.Shuffle(len(walls), func(a, b int) { walls[a], walls[b] = walls[b], walls[a] })
+	for _, e := range walls {
+		j, _ := g.adjacent(e[0], e[1])
+		if a, b := find(e[0]), find(j); a != b {
+			parent[a] = b
+			g.carve(e[0], e[1])
+		}
+	}
+}
+
+// generateWilson is the Wilson generator. The walk from every vertex that isn't in
+// the maze yet remembers the last direction it left every vertex in, which erases
+// the loops, and is carved when it reaches the maze.
+func (g *Graph) generateWilson(rng *rand.Rand) {
+	inMaze := make([]bool, len(g.cells))
+	inMaze[rng.Intn(len(g.cells))] = true
+	next := make([]int, len(g.cells))
+	for _, start := range rng.Perm(len(g.cells)) {
+		for i := start; !inMaze[i]; {
+			d, j := g.randomStep(rng, i)
+			next[i] = d
+			i = j
+		}
+		for i := start; !inMaze[i]; {
+			inMaze[i] = true
+			i = g.carve(i, next[i])
+		}
+	}
+}
+
+// generateAldousBroder is the AldousBroder generator.
+func (g *Graph) generateAldousBroder(rng *rand.Rand) {
+	visited := make([]bool, len(g.cells))
+	i := rng.Intn(len(g.cells))
+	visited[i] = true
+	for remaining := len(g.cells) - 1; remaining > 0; {
+		d, j := g.randomStep(rng, i)
+		if !visited[j] {
+			g.carve(i, d)
+			visited[j] = true
+			remaining--
+		}
+		i = j
+	}
+}
+
+// generateBinaryTree is the BinaryTree generator.
+func (g *Graph) generateBinaryTree(rng *rand.Rand) {
+	dirs := make([]int, 0, 2)
+	for i := range g.cells {
+		dirs = dirs[:0]
+		for _, d := range []int{0, 1} {
+			if _, ok := g.adjacent(i, d); ok {
+				dirs = append(dirs, d)
+			}
+		}
+		if len(dirs) > 0 {
+			g.carve(i, dirs[rng.Intn(len(dirs))])
+		}
+	}
+}
+
+// generateSidewinder is the Sidewinder generator, where the run of a row starts at
+// the index run and is closed by carving north from a random vertex in it.
+func (g *Graph) generateSidewinder(rng *rand.Rand) {
+	for y := 0; y < g.height; y++ {
+		run := y * g.width
+		for x := 0; x < g.width; x++ {
+			i := y*g.width + x
+			east := x < g.width-1
+			if y == 0 || east && rng.Intn(2) == 0 {
+				if east {
+					g.carve(i, 1)
+				}
+				continue
+			}
+			g.carve(run+rng.Intn(i-run+1), 0)
+			run = i + 1
+		}
+	}
+}
+
+// generateDivision is the RecursiveDivision generator, with an explicit stack of
+// the chambers left to divide as the row, column, height and width of the chamber.
+func (g *Graph) generateDivision(rng *rand.Rand) {
+	build := func(i int, d int) {
+		j, _ := g.adjacent(i, d)
+		g.cells[i] |= wall(d)
+		g.cells[j] |= wall(opposite(d))
+	}
+	stack := [][4]int{{0, 0, g.height, g.width}}
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		y, x, h, w := c[0], c[1], c[2], c[3]
+		if h < 2 || w < 2 {
+			continue
+		}
+		if h > w || h == w && rng.Intn(2) == 0 {
+			// A horizontal wall below the row at, with a gap at one column.
+			at, gap := y+rng.Intn(h-1), x+rng.Intn(w)
+			for col := x; col < x+w; col++ {
+				if col != gap {
+					build(at*g.width+col, 2)
+				}
+			}
+			stack = append(stack, [4]int{y, x, at - y + 1, w}, [4]int{at + 1, x, y + h - at - 1, w})
+		} else {
+			// A vertical wall east of the column at, with a gap at one row.
+			at, gap := x+rng.Intn(w-1), y+rng.Intn(h)
+			for row := y; row < y+h; row++ {
+				if row != gap {
+					build(row*g.width+at, 1)
+				}
+			}
+			stack = append(stack, [4]int{y, x, h, at - x + 1}, [4]int{y, at + 1, h, x + w - at - 1})
+		}
+	}
+}
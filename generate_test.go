@@ -0,0 +1,64 @@
+package maze
+
+import (
+	"errors"
+	"testing"
+)
+
+// isPerfect reports whether every vertex of the graph can be reached from the
+// first vertex and the graph has no cycles, i.e. it has exactly one edge less
+// than it has vertices.
+func isPerfect(g *Graph) bool {
+	edges := 0
+	for i := range g.cells {
+		edges += passages(g, g.coord(i))
+	}
+	predecessor, _, _ := g.bfs([]int{0}, -1)
+	for _, p := range predecessor {
+		if p < 0 {
+			return false
+		}
+	}
+	return edges/2 == len(g.cells)-1
+}
+
+func TestGenerate(t *testing.T) {
+	for gen := RecursiveBacktracker; gen <= RecursiveDivision; gen++ {
+		for _, size := range [][2]int{{1, 1}, {1, 7}, {6, 1}, {8, 13}, {20, 20}} {
+			g, _ := New(size[0], size[1])
+			g.AddObstacle(1, 1)
+			if err := g.Generate(gen, 42); err != nil {
+				t.Fatalf("g.Generate(%v, 42) = %v, expected: <nil>", gen, err)
+			}
+			if !isPerfect(g) {
+				t.Errorf("g.Generate(%v, 42) on %vx%v isn't a perfect maze:\n%v", gen, size[0], size[1], g)
+			}
+		}
+
+		g, _ := New(10, 10)
+		h, _ := New(10, 10)
+		g.Generate(gen, 7)
+		h.Generate(gen, 7)
+		if g.String() != h.String() {
+			t.Errorf("g.Generate(%v, 7) gave different mazes for the same seed", gen)
+		}
+		h.Generate(gen, 8)
+		if g.String() == h.String() {
+			t.Errorf("g.Generate(%v, 8) gave the same maze as seed 7", gen)
+		}
+		g.AddStart(1, 1)
+		g.AddFinish(10, 10)
+		if _, _, err := g.FastestPath(); err != nil {
+			t.Errorf("%v: g.FastestPath() error = %v, expected: <nil>", gen, err)
+		}
+	}
+
+	g, _ := New(3, 3, WithDiagonals(CornersAllowed))
+	if err := g.Generate(Prim, 1); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("g.Generate(Prim, 1) WithDiagonals = %v, expected: %v", err, ErrUnsupported)
+	}
+	g, _ = New(3, 3)
+	if err := g.Generate(Generator(8), 1); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("g.Generate(Generator(8), 1) = %v, expected: %v", err, ErrUnsupported)
+	}
+}
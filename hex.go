@@ -0,0 +1,96 @@
+package maze
+
+import "strings"
+
+// hexagonal is the passage flags of the six directions of a hex grid,
+// i.e. north, east, south, west, north-east and south-west.
+const hexagonal cell = orthogonal | 1<<4 | 1<<6
+
+// WithHex creates a hex grid, where every vertex is a hexagon with six neighbours.
+//
+// The vertices have axial coordinates, i.e. the vertex (y,x) has edges to the vertices:
+// (y-1,x), (y-1,x+1), (y,x+1), (y+1,x), (y+1,x-1), (y,x-1)
+// and every row is displayed shifted half a hexagon to the right of the row above,
+// so the graph forms a parallelogram:
+//
+//	 / \ / \ / \
+//	| s |   |   |
+//	 \ / \ / \ / \
+//	  |   |   | f |
+//	   \ / \ / \ /
+//
+// WithHex replaces WithDiagonals, if both are given the last one is used.
+// Hex is the admissible Heuristic for AStar that gives the exact distance
+// in an open hex grid. Chebyshev and Zero are also admissible on a hex grid,
+// but Manhattan and Euclidean overestimate the north-east and south-west steps.
+func WithHex() Option {
+	return func(g *Graph) {
+		g.dirs = hexagonal
+		g.corners = CornersAllowed
+		g.hex = true
+	}
+}
+
+// Hex returns the distance between a and b in a hex grid with axial
+// coordinates, i.e. max(|y1-y2|, |x1-x2|, |y1-y2 + x1-x2|).
+func Hex(a Coord, b Coord) float64 {
+	dy, dx := a.Row-b.Row, a.Col-b.Col
+	result := abs(dy)
+	if abs(dx) > result {
+		result = abs(dx)
+	}
+	if abs(dy+dx) > result {
+		result = abs(dy + dx)
+	}
+	return float64(result)
+}
+
+// renderHex is render for a hex grid, see WithHex. The vertices in marks are displayed
+// with the mark in the middle of the hexagon, the other vertices are empty.
+func (g *Graph) renderHex(marks map[int]string) string {
+	grid := make([][]byte, 2*g.height+1)
+	for i := range grid {
+		grid[i] = []byte(strings.Repeat(" ", 4*g.width+2*g.height-1))
+	}
+	// side draws the wall c at the line and column if there's no passage in
+	// direction d of the vertex i or back from the adjencent vertex, i.e. a
+	// one-way passage is displayed as a gap.
+	side := func(i int, d int, line int, col int, c byte) {
+		if g.cells[i]&passage(d) != 0 {
+			return
+		}
+		if j, ok := g.adjacent(i, d); ok && g.cells[j]&passage(opposite(d)) != 0 {
+			return
+		}
+		grid[line][col] = c
+	}
+	for i := range g.cells {
+		y, x := i/g.width, 4*(i%g.width)+2*(i/g.width)
+		side(i, 0, 2*y, x+1, '/')
+		side(i, 4, 2*y, x+3, '\\')
+		side(i, 3, 2*y+1, x, '|')
+		side(i, 1, 2*y+1, x+4, '|')
+		side(i, 6, 2*y+2, x+1, '\\')
+		side(i, 2, 2*y+2, x+3, '/')
+
+		mark, found := marks[i]
+		if g.cells[i]&startFlag != 0 {
+			mark, found = "s", true
+		}
+		if g.cells[i]&finishFlag != 0 {
+			mark, found = "f", true
+		}
+		if found {
+			mark = shortMark(mark)
+			label := []byte("   ")
+			copy(label[(3-len(mark)+1)/2:], mark)
+			copy(grid[2*y+1][x+1:x+4], label)
+		}
+	}
+
+	var sb strings.Builder
+	for _, line := range grid {
+		sb.WriteString(strings.TrimRight(string(line), " ") + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,68 @@
+package maze
+
+import "testing"
+
+func TestWithHex(t *testing.T) {
+	g, _ := New(3, 3, WithHex())
+	var tests = []struct {
+		c   Coord
+		exp int
+	}{
+		{Coord{2, 2}, 6},
+		{Coord{1, 1}, 2},
+		{Coord{1, 3}, 3},
+		{Coord{3, 1}, 3},
+		{Coord{3, 3}, 2},
+	}
+	for _, e := range tests {
+		if res := passages(g, e.c); res != e.exp {
+			t.Errorf("passages(%v) = %v, expected: %v", e.c, res, e.exp)
+		}
+	}
+
+	h, _ := New(2, 3, WithHex())
+	h.AddStart(2, 1)
+	h.AddFinish(1, 3)
+	exp := "\n / \\ / \\ / \\\n|         f |\n \\           \\\n  | s   p     |\n   \\ / \\ / \\ /\n\ndistance =2"
+	if res := h.StringFastestPath(); res != exp {
+		t.Errorf("h.StringFastestPath() = %q, expected: %q", res, exp)
+	}
+
+	h.AddObstacle(2, 2)
+	exp = " / \\ / \\ / \\\n|         f |\n \\     / \\   \\\n  | s |   |   |\n   \\ / \\ / \\ /\n"
+	if res := h.String(); res != exp {
+		t.Errorf("h.String() = %q, expected: %q", res, exp)
+	}
+	if i, _ := h.GetFastestPath(); i != 2 {
+		t.Errorf("h.GetFastestPath() distance = %v, expected: 2", i)
+	}
+}
+
+func TestHex(t *testing.T) {
+	var tests = []struct {
+		a, b Coord
+		exp  float64
+	}{
+		{Coord{1, 1}, Coord{1, 1}, 0},
+		{Coord{2, 1}, Coord{1, 2}, 1},
+		{Coord{1, 1}, Coord{2, 2}, 2},
+		{Coord{1, 1}, Coord{4, 3}, 5},
+		{Coord{4, 1}, Coord{1, 3}, 3},
+	}
+	for _, e := range tests {
+		if res := Hex(e.a, e.b); res != e.exp {
+			t.Errorf("Hex(%v, %v) = %v, expected: %v", e.a, e.b, res, e.exp)
+		}
+	}
+
+	g, _ := New(8, 8, WithHex())
+	g.AddStart(8, 1)
+	g.AddFinish(1, 8)
+	for i := 2; i <= 7; i++ {
+		g.AddObstacle(i, 4)
+	}
+	dist, _ := g.GetFastestPath()
+	if res, _, _, _ := g.AStar(Hex); res != dist {
+		t.Errorf("g.AStar(Hex) distance = %v, expected: %v", res, dist)
+	}
+}
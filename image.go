@@ -0,0 +1,147 @@
+package maze
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// ImageOptions contains the size and colours of the image written by WriteImage.
+type ImageOptions struct {
+	// CellSize is the width and height of a vertex in pixels, including the wall on
+	// its top and left side, and WallThickness is the thickness of a wall in pixels.
+	CellSize      int
+	WallThickness int
+
+	// The colours of the open vertices, walls, obstacles, start- and finishVertices
+	// and the vertices on the path. A nil colour is the colour of DefaultImageOptions.
+	Background color.Color
+	Wall       color.Color
+	Obstacle   color.Color
+	Start      color.Color
+	Finish     color.Color
+	Path       color.Color
+}
+
+// DefaultImageOptions is the options of a black and white maze with a green start, a
+// red finish and a blue path.
+var DefaultImageOptions = ImageOptions{
+	CellSize:      16,
+	WallThickness: 2,
+	Background:    color.White,
+	Wall:          color.Black,
+	Obstacle:      color.Gray{Y: 0x40},
+	Start:         color.RGBA{0x00, 0xa0, 0x00, 0xff},
+	Finish:        color.RGBA{0xd0, 0x00, 0x00, 0xff},
+	Path:          color.RGBA{0x40, 0x80, 0xff, 0xff},
+}
+
+// The method WriteImage writes the graph to w as a PNG image, where every vertex is a
+// square of CellSize pixels and the edges that can't be taken either way are walls of
+// WallThickness pixels, like the ASCII representation of String. The vertices on the
+// path of GetFastestPath are coloured with the Path colour, and one-way passages are
+// drawn as gaps in the walls.
+//
+// WriteImage returns an error wrapping ErrInvalidArgument if CellSize < 1 or if
+// WallThickness isn't 0 to CellSize-1, an error wrapping ErrUnsupported for hex grids
+// and the error of w.
+func (g *Graph) WriteImage(w io.Writer, opts ImageOptions) error {
+	if g.hex {
+		return fmt.Errorf("%w: hex grids can't be written as images", ErrUnsupported)
+	}
+	if opts.CellSize < 1 || opts.WallThickness < 0 || opts.WallThickness >= opts.CellSize {
+		return fmt.Errorf("%w: cell size %d and wall thickness %d", ErrInvalidArgument, opts.CellSize, opts.WallThickness)
+	}
+	for _, e := range []struct {
+		c   *color.Color
+		def color.Color
+	}{
+		{&opts.Background, DefaultImageOptions.Background},
+		{&opts.Wall, DefaultImageOptions.Wall},
+		{&opts.Obstacle, DefaultImageOptions.Obstacle},
+		{&opts.Start, DefaultImageOptions.Start},
+		{&opts.Finish, DefaultImageOptions.Finish},
+		{&opts.Path, DefaultImageOptions.Path},
+	} {
+		if *e.c == nil {
+			*e.c = e.def
+		}
+	}
+
+	size, t := opts.CellSize, opts.WallThickness
+	img := image.NewRGBA(image.Rect(0, 0, g.width*size+t, g.height*size+t))
+	fill := func(x0 int, y0 int, x1 int, y1 int, c color.Color) {
+		for y := y0; y < y1; y++ {
+			for x := x0; x < x1; x++ {
+				img.Set(x, y, c)
+			}
+		}
+	}
+	fill(0, 0, img.Rect.Dx(), img.Rect.Dy(), opts.Background)
+
+	_, path := g.GetFastestPath()
+	onPath := make(map[int]bool, len(path))
+	for _, c := range path {
+		onPath[g.index(c)] = true
+	}
+	for i := range g.cells {
+		x0, y0 := i%g.width*size, i/g.width*size
+		switch {
+		case g.cells[i]&startFlag != 0:
+			fill(x0+t, y0+t, x0+size, y0+size, opts.Start)
+		case g.cells[i]&finishFlag != 0:
+			fill(x0+t, y0+t, x0+size, y0+size, opts.Finish)
+		case g.cells[i]&obstacleFlag != 0:
+			fill(x0+t, y0+t, x0+size, y0+size, opts.Obstacle)
+		case onPath[i]:
+			fill(x0+t, y0+t, x0+size, y0+size, opts.Path)
+		}
+	}
+
+	// Every wall covers the corners at both of its ends, so the walls are joined.
+	for i := range g.cells {
+		x0, y0 := i%g.width*size, i/g.width*size
+		for d := 0; d < 4; d++ {
+			if g.edgeLabel(i, d, "open", "closed", "open", "open") != "closed" {
+				continue
+			}
+			switch d {
+			case 0:
+				fill(x0, y0, x0+size+t, y0+t, opts.Wall)
+			case 1:
+				fill(x0+size, y0, x0+size+t, y0+size+t, opts.Wall)
+			case 2:
+				fill(x0, y0+size, x0+size+t, y0+size+t, opts.Wall)
+			case 3:
+				fill(x0, y0, x0+t, y0+size+t, opts.Wall)
+			}
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// The function FromImage creates a graph from an image where every pixel is a vertex,
+// e.g. a black and white bitmap drawn in a paint program, with the same options as New.
+// A pixel whose gray level, 0-255, is below the threshold is an obstacle, so dark pixels
+// are obstacles and light pixels open vertices with a threshold of 128.
+//
+// FromImage returns the errors of New, e.g. an error wrapping ErrInvalidSize for an
+// empty image.
+func FromImage(img image.Image, threshold uint8, options ...Option) (*Graph, error) {
+	bounds := img.Bounds()
+	g, err := New(bounds.Dy(), bounds.Dx(), options...)
+	if err != nil {
+		return nil, err
+	}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y < threshold {
+				g.cells[(y-bounds.Min.Y)*g.width+x-bounds.Min.X] |= obstacleFlag
+			}
+		}
+	}
+	g.refreshAll()
+	return g, nil
+}
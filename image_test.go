@@ -0,0 +1,101 @@
+package maze
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestWriteImage(t *testing.T) {
+	g, _ := New(3, 4)
+	g.AddStart(1, 1)
+	g.AddFinish(3, 4)
+	g.AddObstacle(2, 2)
+	g.AddWall(Coord{1, 2}, Coord{1, 3})
+	var buf bytes.Buffer
+	opts := ImageOptions{CellSize: 10, WallThickness: 2, Path: color.RGBA{0, 0, 0xff, 0xff}}
+	if err := g.WriteImage(&buf, opts); err != nil {
+		t.Fatalf("g.WriteImage() error = %v, expected: <nil>", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v, expected: <nil>", err)
+	}
+	if size := img.Bounds().Size(); size != image.Pt(42, 32) {
+		t.Errorf("g.WriteImage() size = %v, expected: (42,32)", size)
+	}
+
+	// center returns the colour in the middle of the vertex c.
+	center := func(c Coord) color.Color {
+		return img.At((c.Col-1)*10+6, (c.Row-1)*10+6)
+	}
+	var tests = []struct {
+		at  color.Color
+		exp color.Color
+		msg string
+	}{
+		{center(Coord{1, 1}), DefaultImageOptions.Start, "start"},
+		{center(Coord{3, 4}), DefaultImageOptions.Finish, "finish"},
+		{center(Coord{2, 2}), DefaultImageOptions.Obstacle, "obstacle"},
+		{center(Coord{2, 1}), opts.Path, "path"},
+		{center(Coord{1, 4}), DefaultImageOptions.Background, "open vertex"},
+		{img.At(20, 5), DefaultImageOptions.Wall, "wall between (1,2) and (1,3)"},
+		{img.At(10, 5), DefaultImageOptions.Background, "gap between (1,1) and (1,2)"},
+		{img.At(0, 15), DefaultImageOptions.Wall, "left border"},
+	}
+	for _, e := range tests {
+		if !sameColour(e.at, e.exp) {
+			t.Errorf("g.WriteImage() %v = %v, expected: %v", e.msg, e.at, e.exp)
+		}
+	}
+
+	for _, opts := range []ImageOptions{{CellSize: 0}, {CellSize: 4, WallThickness: 4}, {CellSize: 4, WallThickness: -1}} {
+		if err := g.WriteImage(&buf, opts); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("g.WriteImage(%+v) = %v, expected: %v", opts, err, ErrInvalidArgument)
+		}
+	}
+	g, _ = New(2, 2, WithHex())
+	if err := g.WriteImage(&buf, DefaultImageOptions); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("g.WriteImage() WithHex = %v, expected: %v", err, ErrUnsupported)
+	}
+}
+
+func sameColour(a color.Color, b color.Color) bool {
+	r1, g1, b1, a1 := a.RGBA()
+	r2, g2, b2, a2 := b.RGBA()
+	return r1 == r2 && g1 == g2 && b1 == b2 && a1 == a2
+}
+
+func TestFromImage(t *testing.T) {
+	rows := []string{
+		"..#.",
+		"#...",
+		"..##",
+	}
+	img := image.NewGray(image.Rect(5, 5, 9, 8))
+	for y, row := range rows {
+		for x, r := range row {
+			img.SetGray(5+x, 5+y, color.Gray{Y: 0xff})
+			if r == '#' {
+				img.SetGray(5+x, 5+y, color.Gray{Y: 0x30})
+			}
+		}
+	}
+	g, err := FromImage(img, 128)
+	if err != nil {
+		t.Fatalf("FromImage() error = %v, expected: <nil>", err)
+	}
+	exp, _ := FromRows(rows, DefaultLegend)
+	if g.String() != exp.String() {
+		t.Errorf("FromImage() = \n%v, expected: \n%v", g, exp)
+	}
+	if g, _ := FromImage(img, 0x20); g.Rows(DefaultLegend)[0] != "...." {
+		t.Errorf("FromImage() with threshold 0x20 = %q, expected no obstacles", g.Rows(DefaultLegend))
+	}
+	if _, err := FromImage(image.NewGray(image.Rect(0, 0, 0, 0)), 128); !errors.Is(err, ErrInvalidSize) {
+		t.Errorf("FromImage() of an empty image = %v, expected: %v", err, ErrInvalidSize)
+	}
+}
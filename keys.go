@@ -0,0 +1,190 @@
+package maze
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// maxKeyStates is the largest number of states, i.e. pairs of a vertex and the keys
+// held when visiting it, that KeyPath searches, which limits the memory it uses.
+const maxKeyStates = 1 << 25
+
+// The method SetKey places a key with the colour, 0-9, at the specified vertex. A key
+// is picked up by visiting its vertex and opens every door with the same colour, see
+// SetDoor and KeyPath. Setting a key where there already is one changes its colour.
+//
+// SetKey returns an error if the vertex is outside of the graph or is an obstacle,
+// and an error wrapping ErrInvalidColour if the colour isn't 0-9.
+func (g *Graph) SetKey(c Coord, colour int) error {
+	i, err := g.keyVertex(c, colour)
+	if err != nil {
+		return err
+	}
+	if g.keys == nil {
+		g.keys = make(map[int]int)
+	}
+	g.keys[i] = colour
+	return nil
+}
+
+// The method SetDoor places a door with the colour, 0-9, at the specified vertex, which
+// can only be visited by KeyPath after a key with the same colour has been picked up.
+// The other path finders ignore the keys and doors.
+//
+// SetDoor returns the same errors as SetKey.
+func (g *Graph) SetDoor(c Coord, colour int) error {
+	i, err := g.keyVertex(c, colour)
+	if err != nil {
+		return err
+	}
+	if g.doors == nil {
+		g.doors = make(map[int]int)
+	}
+	g.doors[i] = colour
+	return nil
+}
+
+// The method ClearKey removes the key at the specified vertex. Removing a key that
+// doesn't exist does nothing.
+//
+// ClearKey returns an error if the vertex is outside of the graph.
+func (g *Graph) ClearKey(c Coord) error {
+	if err := g.checkBounds(c); err != nil {
+		return err
+	}
+	delete(g.keys, g.index(c))
+	return nil
+}
+
+// The method ClearDoor removes the door at the specified vertex, like ClearKey.
+func (g *Graph) ClearDoor(c Coord) error {
+	if err := g.checkBounds(c); err != nil {
+		return err
+	}
+	delete(g.doors, g.index(c))
+	return nil
+}
+
+// keyVertex returns the index of the vertex c for SetKey and SetDoor. It returns an
+// error if c is outside of the graph or is an obstacle or if the colour isn't 0-9.
+func (g *Graph) keyVertex(c Coord, colour int) (int, error) {
+	if err := g.checkBounds(c); err != nil {
+		return 0, err
+	}
+	if colour < 0 || colour > 9 {
+		return 0, fmt.Errorf("%w: %v has colour %d", ErrInvalidColour, c, colour)
+	}
+	i := g.index(c)
+	if g.cells[i]&obstacleFlag != 0 {
+		return 0, fmt.Errorf("%w: %v", ErrIsObstacle, c)
+	}
+	return i, nil
+}
+
+// The method KeyPath returns the shortest distance between the start- and finishvertex
+// when the doors can only be passed with a key of the same colour, and a slice of the
+// coordinates on the shortest route, including the detours to pick up the keys.
+//
+// A picked up key is kept and opens its doors any number of times. The route can visit
+// a vertex more than once, e.g. when it has to go back through a corridor after picking
+// up a key at its end. With several start- or finishVertices the route is the shortest
+// one from any startVertex to the nearest finishVertex.
+//
+// KeyPath returns an error wrapping ErrNoPath if the start- or finishVertex isn't set or
+// if the puzzle can't be solved, i.e. no finishVertex can be reached with the keys that
+// can be picked up, and an error wrapping ErrUnsupported if the graph with keys is too
+// large for the number of key colours.
+func (g *Graph) KeyPath() (int, []Coord, error) {
+	if err := g.checkEndpoints(); err != nil {
+		return 0, nil, err
+	}
+
+	// bits contains the bit of every colour that has a key in the held keys of a
+	// state, which has the index held*n + i where i is the index of the vertex.
+	bits := make(map[int]int)
+	for colour := 0; colour <= 9; colour++ {
+		for _, c := range g.keys {
+			if c == colour {
+				bits[colour] = len(bits)
+				break
+			}
+		}
+	}
+	// Without keys there's one state per vertex, like in the other path finders, so
+	// the limit only applies to the graphs with keys.
+	n := len(g.cells)
+	if len(bits) > 0 && n > maxKeyStates>>len(bits) {
+		return 0, nil, fmt.Errorf("%w: %d vertices with %d key colours", ErrUnsupported, n, len(bits))
+	}
+	pickUp := func(i int, held int) int {
+		if colour, found := g.keys[i]; found {
+			held |= 1 << bits[colour]
+		}
+		return held
+	}
+	opens := func(i int, held int) bool {
+		colour, found := g.doors[i]
+		if !found {
+			return true
+		}
+		bit, found := bits[colour]
+		return found && held&(1<<bit) != 0
+	}
+
+	// The search is the layered BFS of search over the states instead of the vertices.
+	var sources []int
+	for _, i := range g.starts {
+		sources = append(sources, pickUp(i, 0)*n+i)
+	}
+	found := func(s int) bool {
+		return g.cells[s%n]&finishFlag != 0
+	}
+	successors := func(s int, yield func(t int, length int) bool) {
+		held := s / n
+		g.neighbours(s%n, func(x int, length int) bool {
+			return !opens(x, held) || yield(pickUp(x, held)*n+x, length)
+		})
+	}
+	distance, predecessor, _, finish := g.searchStates(n<<len(bits), sources, found, successors, false)
+	if finish < 0 {
+		return 0, nil, fmt.Errorf("%w: no finishVertex can be reached with the keys that can be picked up", ErrNoPath)
+	}
+	return int(distance[finish]), g.tracePath(predecessor, finish), nil
+}
+
+// The method StringKeyPath returns a ASCII representation of the route returned by
+// KeyPath and its distance, like StringFastestPath.
+//
+// The keys are displayed as: (k3 ), or (pk3) on the route, and the doors
+// as: (D3 ), or (pD3) on the route, where 3 is the colour.
+func (g *Graph) StringKeyPath() string {
+	distance, path, err := g.KeyPath()
+	return g.stringPath(path, "distance =", distance, err)
+}
+
+// keyMarks returns the marks with every key marked as "k" and every door as "D",
+// followed by the colour. A vertex with both a key and a door is marked as a door.
+// A mark that is already there, like the "p" of a path, is kept in front of it if
+// there's room for both.
+func (g *Graph) keyMarks(marks map[int]string) map[int]string {
+	if len(g.keys) == 0 && len(g.doors) == 0 {
+		return marks
+	}
+	result := make(map[int]string, len(marks)+len(g.keys)+len(g.doors))
+	for i, mark := range marks {
+		result[i] = mark
+	}
+	for _, e := range []struct {
+		prefix  string
+		colours map[int]int
+	}{{"k", g.keys}, {"D", g.doors}} {
+		for i, colour := range e.colours {
+			label := e.prefix + strconv.Itoa(colour)
+			if len(marks[i]+label) <= 3 {
+				label = marks[i] + label
+			}
+			result[i] = label
+		}
+	}
+	return result
+}
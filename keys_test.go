@@ -0,0 +1,90 @@
+package maze
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestKeyPath(t *testing.T) {
+	g, _ := New(1, 5)
+	g.AddStart(1, 2)
+	g.AddFinish(1, 5)
+	if err := g.SetDoor(Coord{1, 4}, 3); err != nil {
+		t.Fatalf("g.SetDoor((1,4), 3) = %v, expected: <nil>", err)
+	}
+	if _, _, err := g.KeyPath(); !errors.Is(err, ErrNoPath) {
+		t.Errorf("g.KeyPath() error = %v, expected: %v", err, ErrNoPath)
+	}
+	if i, _ := g.GetFastestPath(); i != 3 {
+		t.Errorf("g.GetFastestPath() = %v, expected: 3 through the door", i)
+	}
+
+	g.SetKey(Coord{1, 1}, 5)
+	if _, _, err := g.KeyPath(); !errors.Is(err, ErrNoPath) {
+		t.Errorf("g.KeyPath() with the wrong key error = %v, expected: %v", err, ErrNoPath)
+	}
+	g.SetKey(Coord{1, 1}, 3)
+	exp := []Coord{{1, 2}, {1, 1}, {1, 2}, {1, 3}, {1, 4}, {1, 5}}
+	if i, s, err := g.KeyPath(); err != nil || i != 5 || !coordSliceEq(s, exp) {
+		t.Errorf("g.KeyPath() = %v, %v, %v; expected: %v, %v, <nil>", i, s, err, 5, exp)
+	}
+	expString := "\n.-------.-------.-------.-------.-------.\n| (pk3)   ( s )   ( p )   (pD3)   ( f ) |\n'-------'-------'-------'-------'-------'\n\ndistance =5"
+	if res := g.StringKeyPath(); res != expString {
+		t.Errorf("g.StringKeyPath() = %v, expected: %v", res, expString)
+	}
+
+	g.AddPortal(Coord{1, 1}, Coord{1, 4}, 2)
+	if i, _, _ := g.KeyPath(); i != 4 {
+		t.Errorf("g.KeyPath() = %v, expected: 4 through the portal", i)
+	}
+	g.ClearKey(Coord{1, 1})
+	if _, _, err := g.KeyPath(); !errors.Is(err, ErrNoPath) {
+		t.Errorf("g.KeyPath() after ClearKey error = %v, expected: %v", err, ErrNoPath)
+	}
+	g.ClearDoor(Coord{1, 4})
+	if i, _, err := g.KeyPath(); err != nil || i != 3 {
+		t.Errorf("g.KeyPath() after ClearDoor = %v, %v; expected: 3, <nil>", i, err)
+	}
+
+	// The limit on the states only applies to the graphs with keys.
+	large, _ := New(200, 200)
+	large.AddStart(1, 1)
+	large.AddFinish(200, 200)
+	for colour := 0; colour <= 9; colour++ {
+		large.SetKey(Coord{2, colour + 1}, colour)
+	}
+	if _, _, err := large.KeyPath(); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("large.KeyPath() with 10 key colours error = %v, expected: %v", err, ErrUnsupported)
+	}
+	for colour := 0; colour <= 9; colour++ {
+		large.ClearKey(Coord{2, colour + 1})
+	}
+	if i, _, err := large.KeyPath(); err != nil || i != 398 {
+		t.Errorf("large.KeyPath() without keys = %v, %v; expected: 398, <nil>", i, err)
+	}
+}
+
+func TestSetKey(t *testing.T) {
+	g, _ := New(2, 2)
+	g.AddObstacle(2, 2)
+	var tests = []struct {
+		c      Coord
+		colour int
+		exp    error
+	}{
+		{Coord{1, 1}, 0, nil},
+		{Coord{1, 2}, 9, nil},
+		{Coord{1, 1}, 10, ErrInvalidColour},
+		{Coord{1, 1}, -1, ErrInvalidColour},
+		{Coord{3, 1}, 0, ErrOutOfBounds},
+		{Coord{2, 2}, 0, ErrIsObstacle},
+	}
+	for _, e := range tests {
+		if err := g.SetKey(e.c, e.colour); !errors.Is(err, e.exp) {
+			t.Errorf("g.SetKey(%v, %v) = %v, expected: %v", e.c, e.colour, err, e.exp)
+		}
+		if err := g.SetDoor(e.c, e.colour); !errors.Is(err, e.exp) {
+			t.Errorf("g.SetDoor(%v, %v) = %v, expected: %v", e.c, e.colour, err, e.exp)
+		}
+	}
+}
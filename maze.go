@@ -6,15 +6,68 @@
 // When a maze has multiple solutions one might wanna find the shortest possible path from start
 // to finish. The BFS method of finding the shortest possible path uses a queue to visit vertice
 // with increasing distance from the start-vertex and thereby finds the shortest possible path.
-//
 package maze
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 )
 
+// Errors returned by the error-returning API of the package. The returned
+// errors wrap these values, so they can be inspected with errors.Is.
+var (
+	// ErrInvalidSize is returned when a graph is created with a width or height <= 0.
+	ErrInvalidSize = errors.New("maze: width or height <= 0")
+
+	// ErrOutOfBounds is returned when a coordinate is outside of the graph.
+	ErrOutOfBounds = errors.New("maze: coordinate out of bounds")
+
+	// ErrIsObstacle is returned when the specified vertex is an obstacle.
+	ErrIsObstacle = errors.New("maze: the specified vertex is an obstacle")
+
+	// ErrIsStart is returned when the specified vertex is a startVertex.
+	ErrIsStart = errors.New("maze: the specified vertex is a startVertex")
+
+	// ErrIsFinish is returned when the specified vertex is a finishVertex.
+	ErrIsFinish = errors.New("maze: the specified vertex is a finishVertex")
+
+	// ErrNoPath is returned when there's no path between the start- and finishVertex.
+	ErrNoPath = errors.New("maze: no path between the start- and finishVertex")
+
+	// ErrInvalidCost is returned when a vertex is given a cost < 1.
+	ErrInvalidCost = errors.New("maze: cost < 1")
+
+	// ErrUnsupported is returned when a feature isn't supported for the kind of graph.
+	ErrUnsupported = errors.New("maze: not supported for this graph")
+
+	// ErrNotAdjacent is returned when two vertices that should share an edge aren't adjencent.
+	ErrNotAdjacent = errors.New("maze: the vertices aren't adjencent")
+
+	// ErrInvalidColour is returned when a key or door is given a colour outside of 0-9.
+	ErrInvalidColour = errors.New("maze: colour outside of 0-9")
+
+	// ErrInvalidArgument is returned when a generator is given parameters outside of their range.
+	ErrInvalidArgument = errors.New("maze: invalid argument")
+
+	// ErrInvalidFormat is returned when a graph that is read isn't on the expected format.
+	ErrInvalidFormat = errors.New("maze: invalid format")
+)
+
+// Coord is the coordinate of a vertex in the graph, where Row is the y- and
+// Col is the x-coordinate. The top left vertex has the coordinate (1,1).
+type Coord struct {
+	Row int
+	Col int
+}
+
+// The method String returns the coordinate on the format "(y,x)".
+func (c Coord) String() string {
+	return "(" + strconv.Itoa(c.Row) + "," + strconv.Itoa(c.Col) + ")"
+}
+
 // Graph represents a maze-graph.
 //
 // A graph where every vertex is represented by a 2D coordinate
@@ -22,6 +75,8 @@ import (
 // can have edges between itself and adjencent (non-diagonal) vertices,
 // i.e., if we're given a vertices (x,y) we know that it can only have edges to the vertices:
 // (x+1,y), (x-1,y), (x,y+1), (x,y-1)
+// A graph created WithDiagonals also has edges to the diagonal vertices,
+// and a graph created WithHex is a hex grid with six neighbours.
 type Graph struct {
 	// Width and Height are integers representing the graph's size.
 	//
@@ -41,312 +96,520 @@ type Graph struct {
 	height int
 	width  int
 
-	// Start and finish contains the unique keys to the start- and finishVertex.
-	start  string
-	finish string
+	// Starts and finishes contains the indices in cells of the start- and
+	// finishVertices, in the order they were added.
+	starts   []int
+	finishes []int
+
+	// cells contains the bitflags of every vertex in the graph. The vertex (y,x)
+	// is stored at index (y-1)*width + (x-1), see the methods index and coord.
+	cells []cell
+
+	// costs contains the cost of moving into every vertex, with the same indices
+	// as cells. It's nil until a cost is set, which means that every cost is 1.
+	costs []int32
+
+	// dirs contains the passage flags of the directions the graph has edges in,
+	// and corners decides when a diagonal edge can pass an obstacle.
+	dirs    cell
+	corners CornerPolicy
+
+	// hex is true if the graph is a hex grid, see WithHex.
+	hex bool
+
+	// portals contains the portals going from every vertex, keyed by the
+	// index of the vertex. It's nil until a portal is added, see AddPortal.
+	portals map[int][]portal
+
+	// keys and doors contains the colour of every key and door, keyed by the
+	// index of the vertex. They're nil until a key or door is set, see SetKey.
+	keys  map[int]int
+	doors map[int]int
+
+	// schedules contains the schedule of every vertex that is blocked at some
+	// time steps, keyed by the index of the vertex, see SetSchedule.
+	schedules map[int]Schedule
 
-	// vertices is a map containing pointers to all vertices in the graph.
-	vertices map[string]*vertex
+	// rooms contains the rooms placed by GenerateDungeon, or nil if the graph
+	// isn't a dungeon.
+	rooms []Room
+
+	// wrap contains the directions the graph wraps around in, see WithWrap.
+	wrap Wrap
 }
 
-// vertex is the object for every vertex in the graph.
-type vertex struct {
-	// key is the unique representation to every vertex in the graph.
-	// Every key has the format "(y,x)", where y and x represents its
-	// coordinates in the graph.
-	key string
+// cell is the bitflags of a vertex in the graph.
+//
+// The passage flags signifies that the vertex has an edge to the adjencent
+// vertex in that direction. The wall flags signifies that a wall has been
+// placed with AddWall between the vertex and the adjencent vertex in that
+// direction, a vertex never has both a wall and a passage in the same direction.
+// The one-way flags signifies that the edge in that direction can only be taken
+// from the adjencent vertex to the vertex, see SetOneWay.
+// A vertex can only have edges to adjencent vertices, i.e., if we're given a
+// vertex (y,x) we know that it can only have edges to the vertices:
+// (y-1,x), (y,x+1), (y+1,x), (y,x-1)
+// and to the diagonal vertices (y-1,x+1), (y+1,x+1), (y+1,x-1), (y-1,x-1) if the
+// graph is created WithDiagonals.
+// [Given that all Coordinates are within the heigth and width specifications]
+type cell uint32
 
-	// start- and finishVertex is a boolean signifying if the vertex is
-	// a start-/finishvertex or not.
-	startVertex  bool
-	finishVertex bool
+const (
+	obstacleFlag cell = 1 << (16 + iota) // the vertex is an obstacle
+	startFlag                            // the vertex is a startVertex
+	finishFlag                           // the vertex is a finishVertex
+)
 
-	// obstacle is a boolean signifying if the vertex is a
-	// obstacle or not.
-	obstacle bool
+// passage returns the passage flag of direction d.
+func passage(d int) cell {
+	return 1 << d
+}
 
-	// visited is a boolean signifying if the vertex has been visited or not.
-	visited bool
+// wall returns the wall flag of direction d.
+func wall(d int) cell {
+	return 1 << (8 + d)
+}
 
-	// neighbours is a map of pointers to the vertices who have edges connected to
-	// the given vertex. A vertex can only have edges to adjencent vertices (non-diagonal),
-	// i.e., if we're given a vertex (x,y) we know that it can only have edges to the vertices:
-	// (x+1,y), (x-1,y), (x,y+1), (x,y-1)
-	// [Given that all Coordinates are within the heigth and width specifications]
-	neighbours map[string]*vertex
+// oneWay returns the one-way flag of direction d.
+func oneWay(d int) cell {
+	return 1 << (19 + d)
+}
+
+// directions contains the offsets to the adjencent vertices in the order
+// north, east, south, west, north-east, south-east, south-west and north-west,
+// which is the same order as the passage and wall flags.
+var directions = [8]struct{ dy, dx int }{{-1, 0}, {0, 1}, {1, 0}, {0, -1}, {-1, 1}, {1, 1}, {1, -1}, {-1, -1}}
+
+// diagonalSides contains the two orthogonal directions that a diagonal
+// direction d passes between, at index d-4.
+var diagonalSides = [4][2]int{{0, 1}, {2, 1}, {2, 3}, {0, 3}}
+
+// orthogonal is the passage flags of north, east, south and west.
+const orthogonal cell = 0x0f
+
+// opposite returns the opposite direction of d.
+func opposite(d int) int {
+	if d < 4 {
+		return (d + 2) % 4
+	}
+	return 4 + (d-2)%4
 }
 
 // NewGraph creates a graph of size, width x Heigth, where every vertex has a edge connected
 // to every adjencent vertex (non-diagonal).
+//
+// If width or height <= 0 an empty Graph is returned, use New to get the error.
 func NewGraph(height int, width int) Graph {
+	g, err := New(height, width)
+	if err != nil {
+		return Graph{}
+	}
+	return *g
+}
+
+// New creates a graph of size, width x height, where every vertex has a edge connected
+// to every adjencent vertex (non-diagonal), unless the options say otherwise.
+//
+// New returns an error wrapping ErrInvalidSize if width or height <= 0.
+func New(height int, width int, options ...Option) (*Graph, error) {
 	if width <= 0 || height <= 0 {
-		defer func() {
-			if err := recover(); err != nil {
-				fmt.Println(err)
-			}
-		}()
-		panic("Error: width or height <= 0")
-	}
-	var graph Graph
-	graph.height = height
-	graph.width = width
-	graph.vertices = make(map[string]*vertex)
-
-	for i := 1; i <= height; i++ {
-		for j := 1; j <= width; j++ {
-			coord := coordinate(i, j)
-			var vert vertex
-			vert.key = coord
-			graph.vertices[coord] = &vert
-		}
+		return nil, fmt.Errorf("%w: %dx%d", ErrInvalidSize, height, width)
+	}
+	graph := &Graph{
+		height: height,
+		width:  width,
+		dirs:   orthogonal,
+		cells:  make([]cell, height*width),
 	}
-	for i := 1; i <= height; i++ {
-		for j := 1; j <= width; j++ {
-			coord := coordinate(i, j)
-			neighbours := make(map[string]*vertex)
-			switch i {
-			case 1:
-				neighbours[coordinate(i+1, j)] = graph.vertices[coordinate(i+1, j)]
-			case height:
-				neighbours[coordinate(i-1, j)] = graph.vertices[coordinate(i-1, j)]
-			default:
-				neighbours[coordinate(i+1, j)] = graph.vertices[coordinate(i+1, j)]
-				neighbours[coordinate(i-1, j)] = graph.vertices[coordinate(i-1, j)]
+	for _, option := range options {
+		option(graph)
+	}
+	if err := graph.checkOptions(); err != nil {
+		return nil, err
+	}
+	// There are no walls or obstacles yet, so every adjencent vertex has an edge.
+	for i := range graph.cells {
+		for d := range directions {
+			if graph.dirs&passage(d) == 0 {
+				continue
 			}
-			switch j {
-			case 1:
-				neighbours[coordinate(i, j+1)] = graph.vertices[coordinate(i, j+1)]
-			case width:
-				neighbours[coordinate(i, j-1)] = graph.vertices[coordinate(i, j-1)]
-			default:
-				neighbours[coordinate(i, j+1)] = graph.vertices[coordinate(i, j+1)]
-				neighbours[coordinate(i, j-1)] = graph.vertices[coordinate(i, j-1)]
+			if _, ok := graph.adjacent(i, d); ok {
+				graph.cells[i] |= passage(d)
 			}
-			graph.vertices[coord].neighbours = neighbours
 		}
 	}
-	return graph
+	return graph, nil
+}
+
+// index returns the index in cells of the vertex with coordinate c.
+func (g *Graph) index(c Coord) int {
+	return (c.Row-1)*g.width + c.Col - 1
 }
 
-// coordinate takes two integers and returns a string representation
-// of the two integers as a coordinate, on the format:
-// "(y,x)"
-func coordinate(y int, x int) string {
-	return "(" + strconv.Itoa(y) + "," + strconv.Itoa(x) + ")"
+// coord returns the coordinate of the vertex at index i in cells.
+func (g *Graph) coord(i int) Coord {
+	return Coord{i/g.width + 1, i%g.width + 1}
+}
+
+// adjacent returns the index of the adjencent vertex in direction d of the
+// vertex at index i, and false if it's outside of the graph. The coordinates
+// wrap around the edges of the graph created WithWrap.
+func (g *Graph) adjacent(i int, d int) (int, bool) {
+	y, x := i/g.width+directions[d].dy, i%g.width+directions[d].dx
+	if g.wrap&WrapVertical != 0 {
+		y = (y + g.height) % g.height
+	}
+	if g.wrap&WrapHorizontal != 0 {
+		x = (x + g.width) % g.width
+	}
+	if y < 0 || y >= g.height || x < 0 || x >= g.width {
+		return 0, false
+	}
+	return y*g.width + x, true
+}
+
+// step returns the index of the adjencent vertex in direction d of the vertex
+// at index i. It should only be used when there's a passage in direction d.
+func (g *Graph) step(i int, d int) int {
+	if g.wrap != 0 {
+		j, _ := g.adjacent(i, d)
+		return j
+	}
+	return i + directions[d].dy*g.width + directions[d].dx
+}
+
+// canPass reports whether the vertex i should have a passage in direction d, i.e.
+// the graph has edges in that direction, the adjencent vertex exists, there's no
+// wall or one-way passage against the direction between them and none of them is
+// an obstacle. A diagonal passage must also be allowed by the CornerPolicy of the graph.
+func (g *Graph) canPass(i int, d int) bool {
+	if g.dirs&passage(d) == 0 || g.cells[i]&(wall(d)|oneWay(d)|obstacleFlag) != 0 {
+		return false
+	}
+	j, ok := g.adjacent(i, d)
+	if !ok || g.cells[j]&obstacleFlag != 0 {
+		return false
+	}
+	if d < 4 || g.corners == CornersAllowed {
+		return true
+	}
+	blocked := 0
+	for _, side := range diagonalSides[d-4] {
+		if k, ok := g.adjacent(i, side); ok && g.cells[k]&obstacleFlag != 0 {
+			blocked++
+		}
+	}
+	if g.corners == CornersBlockedByEither {
+		return blocked == 0
+	}
+	return blocked < 2
+}
+
+// refresh updates the passages in both directions between the vertex i and its
+// adjencent vertex in direction d, see canPass.
+func (g *Graph) refresh(i int, d int) {
+	j, ok := g.adjacent(i, d)
+	if !ok {
+		return
+	}
+	for _, e := range [2]struct{ i, d int }{{i, d}, {j, opposite(d)}} {
+		if g.canPass(e.i, e.d) {
+			g.cells[e.i] |= passage(e.d)
+		} else {
+			g.cells[e.i] &^= passage(e.d)
+		}
+	}
+}
+
+// refreshAround updates every passage that depends on whether the vertex i
+// is an obstacle, i.e. the passages of the vertex and the diagonal passages
+// of its orthogonal neighbours, which can pass its corners.
+func (g *Graph) refreshAround(i int) {
+	for d := range directions {
+		g.refresh(i, d)
+	}
+	if g.dirs&^orthogonal == 0 {
+		return
+	}
+	for d := 0; d < 4; d++ {
+		if j, ok := g.adjacent(i, d); ok {
+			for dd := 4; dd < len(directions); dd++ {
+				g.refresh(j, dd)
+			}
+		}
+	}
 }
 
 // The method String returns a string ASCII representation of the graph
 // with visual representation for vertices, edges, startVertex
 // and finishVertex.
 func (g *Graph) String() string {
-	stringGrid := make([][]string, 2*g.height+1)
-	for i := 0; i <= 2*g.height; i++ {
-		stringGrid[i] = make([]string, g.width+1)
-	}
-
-	stringGrid[0][0] = "."
-	for i := 2; i <= 2*g.height-2; i += 2 {
-		stringGrid[i][0] = ":"
-	}
-	for i := 1; i <= 2*g.height-1; i += 2 {
-		stringGrid[i][0] = "|"
-	}
-	stringGrid[2*g.height][0] = "'"
-	for j := 1; j <= g.width; j++ {
-		stringGrid[0][j] = "-------."
-		idx := 1
-		for i := 2; i <= 2*g.height-2; i += 2 {
-			vertex := g.vertices[coordinate(idx, j)]
-			_, found := vertex.neighbours[coordinate(idx+1, j)]
-			if found {
-				stringGrid[i][j] = "       +"
-			} else {
-				stringGrid[i][j] = "-------+"
+	return g.render(nil, nil)
+}
+
+// render returns the ASCII representation of the graph used by String and
+// StringFastestPath. The vertices in marks are displayed as "( m )", where m
+// is the mark, instead of their coordinate, see markLabel. The portals are marked
+// with letters, see portalMarks, and the keys and doors with their colour, see
+// keyMarks. The diagonal steps of the path are displayed as "\\" or "/" in the
+// corner they pass and the one-way passages as arrows, see edgeLabel. Hex grids
+// are displayed by renderHex.
+func (g *Graph) render(marks map[int]string, path []Coord) string {
+	marks = g.keyMarks(g.portalMarks(marks))
+	if g.hex {
+		return g.renderHex(marks)
+	}
+
+	// corners contains the diagonal steps, keyed by the index of the vertex
+	// to the top left of the corner.
+	corners := make(map[int]byte)
+	for k := 1; k < len(path); k++ {
+		a, b := path[k-1], path[k]
+		if abs(a.Row-b.Row) != 1 || abs(a.Col-b.Col) != 1 {
+			continue
+		}
+		corner := b
+		if a.Row < b.Row {
+			corner.Row = a.Row
+		}
+		if a.Col < b.Col {
+			corner.Col = a.Col
+		}
+		key := g.index(corner)
+		step := byte('/')
+		if a.Row-b.Row == a.Col-b.Col {
+			step = '\\'
+		}
+		if corners[key] != 0 && corners[key] != step {
+			step = 'X'
+		}
+		corners[key] = step
+	}
+
+	// The border only has openings where the graph wraps around, see WithWrap.
+	var sb strings.Builder
+	sb.WriteString(".")
+	for j := 0; j < g.width; j++ {
+		sb.WriteString(g.edgeLabel(j, 0, "       ", "-------", "   ^   ", "   v   ") + ".")
+	}
+	sb.WriteString("\n")
+	for i := 0; i < g.height; i++ {
+		if i > 0 {
+			sb.WriteString(":")
+			for j := 0; j < g.width; j++ {
+				wall := g.edgeLabel((i-1)*g.width+j, 2, "       ", "-------", "   v   ", "   ^   ")
+				if step, found := corners[(i-1)*g.width+j]; found {
+					sb.WriteString(wall + string(step))
+				} else {
+					sb.WriteString(wall + "+")
+				}
 			}
-			idx++
+			sb.WriteString("\n")
 		}
-		idx = 1
-		for i := 1; i <= 2*g.height-1; i += 2 {
-			coord := coordinate(idx, j)
-			if g.vertices[coordinate(idx, j)].startVertex {
-				coord = "( s )"
+		sb.WriteString(g.edgeLabel(i*g.width, 3, " ", "|", "<", ">"))
+		for j := 0; j < g.width; j++ {
+			idx := i*g.width + j
+			label := g.coord(idx).String()
+			if mark, found := marks[idx]; found {
+				label = markLabel(mark)
 			}
-			if g.vertices[coordinate(idx, j)].finishVertex {
-				coord = "( f )"
+			if g.cells[idx]&startFlag != 0 {
+				label = "( s )"
 			}
-			vertex := g.vertices[coordinate(idx, j)]
-			_, found := vertex.neighbours[coordinate(idx, j+1)]
-			if found {
-				stringGrid[i][j] = " " + coord + "  "
-			} else {
-				stringGrid[i][j] = " " + coord + " |"
+			if g.cells[idx]&finishFlag != 0 {
+				label = "( f )"
 			}
-			idx++
+			sb.WriteString(" " + label + " " + g.edgeLabel(idx, 1, " ", "|", ">", "<"))
 		}
-		stringGrid[2*g.height][j] = "-------'"
+		sb.WriteString("\n")
+	}
+	sb.WriteString("'")
+	for j := 0; j < g.width; j++ {
+		sb.WriteString(g.edgeLabel((g.height-1)*g.width+j, 2, "       ", "-------", "   v   ", "   ^   ") + "'")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// edgeLabel returns how the edge in direction d of the vertex i is displayed, i.e. open
+// if it can be taken both ways, forward if it's a one-way passage in direction d, backward
+// if it's a one-way passage in the opposite direction and closed if it can't be taken.
+func (g *Graph) edgeLabel(i int, d int, open string, closed string, forward string, backward string) string {
+	there := g.cells[i]&passage(d) != 0
+	back := false
+	if j, ok := g.adjacent(i, d); ok {
+		back = g.cells[j]&passage(opposite(d)) != 0
+	}
+	switch {
+	case there && back:
+		return open
+	case there:
+		return forward
+	case back:
+		return backward
+	}
+	return closed
+}
+
+// markLabel returns the label of a marked vertex, i.e. the mark in parentheses
+// padded to the width of a coordinate, e.g. "( p )" or "(12 )". A mark longer
+// than 3 characters is shortened, see shortMark.
+func markLabel(mark string) string {
+	mark = shortMark(mark)
+	switch len(mark) {
+	case 1:
+		return "( " + mark + " )"
+	case 2:
+		return "(" + mark + " )"
 	}
-	var result string
-	for i := 0; i <= 2*g.height; i++ {
-		result += strings.Join(stringGrid[i], "") + "\n"
+	return "(" + mark + ")"
+}
+
+// shortMark returns the mark shortened to the 3 characters that fit in a vertex,
+// i.e. a "~" followed by its last 2 characters if it's longer, so the step 1234
+// of a timed path is displayed as "(~34)" and keeps the grid aligned.
+func shortMark(mark string) string {
+	if len(mark) <= 3 {
+		return mark
 	}
-	return result
+	return "~" + mark[len(mark)-2:]
 }
 
 // The method AddObstacle adds an obstacle at the specified vertex,
 // i.e. removes edges between the vertex and its adjencent vertices
-// and changes vertex.obstacle to true.
+// and marks the vertex as an obstacle.
+//
+// AddObstacle does nothing if the vertex is outside of the graph or is a
+// start- or finishVertex, use SetObstacle to get the error.
 func (g *Graph) AddObstacle(y int, x int) {
-	if g.vertices[coordinate(y, x)].startVertex || g.vertices[coordinate(y, x)].finishVertex {
-		defer func() {
-			if err := recover(); err != nil {
-				fmt.Println(err)
-			}
-		}()
-		panic("Error: the specified vertex is a start- or finsihVertex")
+	_ = g.SetObstacle(Coord{y, x})
+}
+
+// The method SetObstacle adds an obstacle at the specified vertex, like AddObstacle,
+// and returns an error if the vertex is outside of the graph or is a
+// start- or finishVertex.
+func (g *Graph) SetObstacle(c Coord) error {
+	if err := g.checkBounds(c); err != nil {
+		return err
 	}
-	switch y {
-	case 1:
-		g.removeEdge(coordinate(y, x), coordinate(y+1, x))
-	case g.height:
-		g.removeEdge(coordinate(y, x), coordinate(y-1, x))
-	default:
-		g.removeEdge(coordinate(y, x), coordinate(y+1, x))
-		g.removeEdge(coordinate(y, x), coordinate(y-1, x))
-	}
-	switch x {
-	case 1:
-		g.removeEdge(coordinate(y, x), coordinate(y, x+1))
-	case g.width:
-		g.removeEdge(coordinate(y, x), coordinate(y, x-1))
-	default:
-		g.removeEdge(coordinate(y, x), coordinate(y, x+1))
-		g.removeEdge(coordinate(y, x), coordinate(y, x-1))
+	i := g.index(c)
+	if g.cells[i]&startFlag != 0 {
+		return fmt.Errorf("%w: %v", ErrIsStart, c)
 	}
-	g.vertices[coordinate(y, x)].obstacle = true
-}
-func (g *Graph) removeEdge(coord1 string, coord2 string) {
-	delete(g.vertices[coord1].neighbours, coord2)
-	delete(g.vertices[coord2].neighbours, coord1)
+	if g.cells[i]&finishFlag != 0 {
+		return fmt.Errorf("%w: %v", ErrIsFinish, c)
+	}
+	g.cells[i] |= obstacleFlag
+	g.refreshAround(i)
+	return nil
 }
 
 // The method RemoveObstacle removes an obstacle at the specified vertex,
 // i.e. adds edges between the vertex and its adjencent vertices, if the
-// adjencent vertex isn't an obstacle. And changes vertex.obstacle to false.
+// adjencent vertex isn't an obstacle and there's no wall between them.
+// And unmarks the vertex as an obstacle.
+//
+// RemoveObstacle does nothing if the vertex is outside of the graph,
+// use ClearObstacle to get the error.
 func (g *Graph) RemoveObstacle(y int, x int) {
-	switch y {
-	case 1:
-		if !g.vertices[coordinate(y+1, x)].obstacle {
-			g.addEdge(coordinate(y, x), coordinate(y+1, x))
-		}
-	case g.height:
-		if !g.vertices[coordinate(y-1, x)].obstacle {
-			g.addEdge(coordinate(y, x), coordinate(y-1, x))
-		}
-	default:
-		if !g.vertices[coordinate(y+1, x)].obstacle {
-			g.addEdge(coordinate(y, x), coordinate(y+1, x))
-		}
-		if !g.vertices[coordinate(y-1, x)].obstacle {
-			g.addEdge(coordinate(y, x), coordinate(y-1, x))
-		}
-	}
-	switch x {
-	case 1:
-		if !g.vertices[coordinate(y, x+1)].obstacle {
-			g.addEdge(coordinate(y, x), coordinate(y, x+1))
-		}
-	case g.width:
-		if !g.vertices[coordinate(y, x-1)].obstacle {
-			g.addEdge(coordinate(y, x), coordinate(y, x-1))
-		}
-	default:
-		if !g.vertices[coordinate(y, x+1)].obstacle {
-			g.addEdge(coordinate(y, x), coordinate(y, x+1))
-		}
-		if !g.vertices[coordinate(y, x-1)].obstacle {
-			g.addEdge(coordinate(y, x), coordinate(y, x-1))
-		}
+	_ = g.ClearObstacle(Coord{y, x})
+}
+
+// The method ClearObstacle removes an obstacle at the specified vertex, like
+// RemoveObstacle, and returns an error if the vertex is outside of the graph.
+func (g *Graph) ClearObstacle(c Coord) error {
+	if err := g.checkBounds(c); err != nil {
+		return err
 	}
-	g.vertices[coordinate(y, x)].obstacle = false
+	i := g.index(c)
+	g.cells[i] &^= obstacleFlag
+	g.refreshAround(i)
+	return nil
 }
-func (g *Graph) addEdge(coord1 string, coord2 string) {
-	g.vertices[coord1].neighbours[coord2] = g.vertices[coord2]
-	g.vertices[coord2].neighbours[coord1] = g.vertices[coord1]
+
+// checkBounds returns an error wrapping ErrOutOfBounds if the coordinate
+// isn't within the heigth and width of the graph.
+func (g *Graph) checkBounds(c Coord) error {
+	if c.Row < 1 || c.Row > g.height || c.Col < 1 || c.Col > g.width {
+		return fmt.Errorf("%w: %v", ErrOutOfBounds, c)
+	}
+	return nil
 }
 
 // The method AddStart marks the specified vertex as the "startVertex".
 //
-// Does this by changing its field startVertex to true, if the specified vertex
-// isn't an obstacle. If there already exists a startVertex, the method
-// also turns the old startvertex into a "normal vertex".
+// Does this by setting its start flag, if the specified vertex
+// isn't an obstacle. If there already exists startVertices, the method
+// also turns the old startvertices into "normal vertices", use AppendStart
+// to keep them.
 //
-// The method updatedsthe field Start of the Graph to match the new
+// The method updateds the field starts of the Graph to match the new
 // startVertex.
+//
+// AddStart does nothing if the vertex is outside of the graph, is an obstacle
+// or is the finishVertex, use SetStart to get the error.
 func (g *Graph) AddStart(y int, x int) {
-	coord := coordinate(y, x)
-	if g.vertices[coord].obstacle {
-		defer func() {
-			if err := recover(); err != nil {
-				fmt.Println(err)
-			}
-		}()
-		panic("Error: the specified vertex is an obstacle")
+	_ = g.SetStart(Coord{y, x})
+}
+
+// The method SetStart marks the specified vertex as the "startVertex", like AddStart,
+// and returns an error if the vertex is outside of the graph, is an obstacle
+// or is the finishVertex.
+func (g *Graph) SetStart(c Coord) error {
+	if err := g.checkBounds(c); err != nil {
+		return err
 	}
-	if g.vertices[coord].finishVertex {
-		defer func() {
-			if err := recover(); err != nil {
-				fmt.Println(err)
-			}
-		}()
-		panic("Error: the specified vertex is a finishVertex")
+	i := g.index(c)
+	if g.cells[i]&obstacleFlag != 0 {
+		return fmt.Errorf("%w: %v", ErrIsObstacle, c)
+	}
+	if g.cells[i]&finishFlag != 0 {
+		return fmt.Errorf("%w: %v", ErrIsFinish, c)
 	}
-	if g.start != "" {
-		g.vertices[g.start].startVertex = false
+	for _, j := range g.starts {
+		g.cells[j] &^= startFlag
 	}
-	g.vertices[coord].startVertex = true
-	g.start = coord
+	g.cells[i] |= startFlag
+	g.starts = append(g.starts[:0], i)
+	return nil
 }
 
 // The method AddFinish marks the specified vertex as the "finishVertex".
 //
-// Does this by changing its field finishVertex to true, if the specified vertex
-// isn't an obstacle. If there already exists a finishVertex, the method
-// also turns the old finishVertex into a "normal vertex".
+// Does this by setting its finish flag, if the specified vertex
+// isn't an obstacle. If there already exists finishVertices, the method
+// also turns the old finishVertices into "normal vertices", use AppendFinish
+// to keep them.
 //
-// The method updateds the field finish of the Graph to match the new
+// The method updateds the field finishes of the Graph to match the new
 // finishVertex.
+//
+// AddFinish does nothing if the vertex is outside of the graph, is an obstacle
+// or is the startVertex, use SetFinish to get the error.
 func (g *Graph) AddFinish(y int, x int) {
-	coord := coordinate(y, x)
-	if g.vertices[coord].obstacle {
-		defer func() {
-			if err := recover(); err != nil {
-				fmt.Println(err)
-			}
-		}()
-		panic("Error: the specified vertex is an obstacle")
+	_ = g.SetFinish(Coord{y, x})
+}
+
+// The method SetFinish marks the specified vertex as the "finishVertex", like AddFinish,
+// and returns an error if the vertex is outside of the graph, is an obstacle
+// or is the startVertex.
+func (g *Graph) SetFinish(c Coord) error {
+	if err := g.checkBounds(c); err != nil {
+		return err
 	}
-	if g.vertices[coord].startVertex {
-		defer func() {
-			if err := recover(); err != nil {
-				fmt.Println(err)
-			}
-		}()
-		panic("Error: the specified vertex is a startVertex")
+	i := g.index(c)
+	if g.cells[i]&obstacleFlag != 0 {
+		return fmt.Errorf("%w: %v", ErrIsObstacle, c)
 	}
-	if g.finish != "" {
-		g.vertices[g.finish].finishVertex = false
+	if g.cells[i]&startFlag != 0 {
+		return fmt.Errorf("%w: %v", ErrIsStart, c)
 	}
-	g.vertices[coord].finishVertex = true
-	g.finish = coord
-}
-
-func (g *Graph) unmarkVisited() {
-	for i := 1; i <= g.height; i++ {
-		for j := 1; j <= g.width; j++ {
-			coord := coordinate(i, j)
-			g.vertices[coord].visited = false
-		}
+	for _, j := range g.finishes {
+		g.cells[j] &^= finishFlag
 	}
+	g.cells[i] |= finishFlag
+	g.finishes = append(g.finishes[:0], i)
+	return nil
 }
 
 // The method StringFastestPath returns a ASCII representation of the shortest
@@ -356,142 +619,222 @@ func (g *Graph) unmarkVisited() {
 // the start-vertex as: ( s )
 // the finish-vertex as: ( f )
 // the path as: ( p )
+// the ends of a portal as: ( a ), or (pa ) on the path, see AddPortal
+//
+// If there's no path the error is displayed instead of the distance.
 func (g *Graph) StringFastestPath() string {
-	distance, path := g.GetFastestPath()
-
-	stringGrid := make([][]string, 2*g.height+1)
-	for i := 0; i <= 2*g.height; i++ {
-		stringGrid[i] = make([]string, g.width+1)
-	}
-
-	stringGrid[0][0] = "\n."
-	for i := 2; i <= 2*g.height-2; i += 2 {
-		stringGrid[i][0] = ":"
-	}
-	for i := 1; i <= 2*g.height-1; i += 2 {
-		stringGrid[i][0] = "|"
-	}
-	stringGrid[2*g.height][0] = "'"
-	for j := 1; j <= g.width; j++ {
-		stringGrid[0][j] = "-------."
-		idx := 1
-		for i := 2; i <= 2*g.height-2; i += 2 {
-			vertex := g.vertices[coordinate(idx, j)]
-			_, found := vertex.neighbours[coordinate(idx+1, j)]
-			if found {
-				stringGrid[i][j] = "       +"
-			} else {
-				stringGrid[i][j] = "-------+"
-			}
-			idx++
-		}
-		idx = 1
-		for i := 1; i <= 2*g.height-1; i += 2 {
-			coord := coordinate(idx, j)
-			if g.vertices[coordinate(idx, j)].startVertex {
-				coord = "( s )"
-			}
-			if g.vertices[coordinate(idx, j)].finishVertex {
-				coord = "( f )"
-			}
-			vertex := g.vertices[coordinate(idx, j)]
-			_, found := vertex.neighbours[coordinate(idx, j+1)]
-			if found {
-				stringGrid[i][j] = " " + coord + "  "
-			} else {
-				stringGrid[i][j] = " " + coord + " |"
-			}
-			idx++
-		}
-		stringGrid[2*g.height][j] = "-------'"
-	}
-	for _, coord := range path {
-		if g.vertices[coord].startVertex || g.vertices[coord].finishVertex {
-			// do nothing
-		} else {
-			y, x := coordToInt(coord)
-			y_grid := 2*y - 1
-			stringGrid[y_grid][x] = makeP(stringGrid[y_grid][x])
-		}
-	}
-	var result string
-	for i := 0; i <= 2*g.height; i++ {
-		result += strings.Join(stringGrid[i], "") + "\n"
-	}
-	return result + "\n" + "distance =" + strconv.Itoa(distance)
+	distance, path, err := g.FastestPath()
+	return g.stringPath(path, "distance =", distance, err)
 }
 
-func makeP(text string) string {
-	slice := make([]string, 8)
-	for idx, x := range text {
-		slice[idx] = string(x)
+// stringPath returns the ASCII representation of the graph with the path
+// marked as "( p )", followed by the name and value of the path's length
+// or the error if it isn't nil.
+func (g *Graph) stringPath(path []Coord, name string, value int, err error) string {
+	marks := make(map[int]string, len(path))
+	for _, c := range path {
+		marks[g.index(c)] = "p"
 	}
-	slice[2] = " "
-	slice[3] = "p"
-	slice[4] = " "
-	return strings.Join(slice, "")
+	return g.stringMarks(marks, path, name, value, err)
 }
 
-func coordToInt(coord string) (int, int) {
-	slice := make([]string, 5)
-	for idx, x := range coord {
-		slice[idx] = string(x)
+// stringMarks is stringPath with any marks, see render.
+func (g *Graph) stringMarks(marks map[int]string, path []Coord, name string, value int, err error) string {
+	result := "\n" + g.render(marks, path)
+	if err != nil {
+		return result + "\n" + err.Error()
 	}
-	result1, _ := strconv.Atoi(slice[1])
-	result2, _ := strconv.Atoi(slice[3])
-	return result1, result2
+	return result + "\n" + name + strconv.Itoa(value)
 }
 
 // The method GetFastestPath returns the shortest distance between the start- and finishvertex
-// and a slice of strings representing the shortest path.
+// and a slice of the coordinates on the shortest path.
 //
 // The slice is on the format:
 // [(1,1), (2,1), ..., (5,4), (5,5)]
-func (g *Graph) GetFastestPath() (int, []string) {
-	distance, predecessor := g.fastestPathBFS()
-	dist := distance[g.finish]
-	stringSlice := make([]string, dist+1)
-	stringSlice[dist] = g.finish
-	stringSlice[0] = g.start
-	vertex := g.finish
-	for j := dist - 1; j >= 1; j-- {
-		stringSlice[j] = predecessor[vertex]
-		vertex = predecessor[vertex]
-	}
-	return dist, stringSlice
-}
-
-func (g *Graph) fastestPathBFS() (map[string]int, map[string]string) {
-	g.unmarkVisited()
-	var queue []*vertex
-	var a *vertex
-	distance := make(map[string]int)
-	predecessor := make(map[string]string)
-
-	g.vertices[g.start].visited = true
-	queue = append(queue, g.vertices[g.start])
-	distance[g.start] = 0
-
-	for len(queue) > 0 {
-		a = queue[0]
-		queue = queue[1:]
-		for _, x := range a.neighbours {
-			if !x.visited {
-				x.visited = true
-				distance[x.key] = distance[a.key] + 1
-				predecessor[x.key] = a.key
-				queue = append(queue, x)
-
-				if x.finishVertex {
-					return distance, predecessor
+//
+// GetFastestPath returns 0 and a nil slice if there's no path, use FastestPath
+// to get the error.
+func (g *Graph) GetFastestPath() (int, []Coord) {
+	dist, path, err := g.FastestPath()
+	if err != nil {
+		return 0, nil
+	}
+	return dist, path
+}
+
+// The method FastestPath returns the shortest distance between the start- and finishvertex
+// and a slice of the coordinates on the shortest path, like GetFastestPath.
+//
+// With several start- or finishVertices the path is the shortest one from any
+// startVertex to the nearest finishVertex, see NearestRoute.
+//
+// FastestPath returns an error wrapping ErrNoPath if the start- or finishVertex
+// isn't set or if there's no path between them.
+func (g *Graph) FastestPath() (int, []Coord, error) {
+	route, err := g.NearestRoute()
+	return route.Distance, route.Path, err
+}
+
+// checkEndpoints returns an error wrapping ErrNoPath if there's no start-
+// or finishVertex.
+func (g *Graph) checkEndpoints() error {
+	if len(g.starts) == 0 || len(g.finishes) == 0 {
+		return fmt.Errorf("%w: start- or finishVertex not set", ErrNoPath)
+	}
+	return nil
+}
+
+// tracePath follows the predecessors from the vertex i back to the vertex
+// that is its own predecessor and returns the path in the opposite order,
+// i.e. from that vertex to i. The predecessors can also be the states of
+// searchStates, where the vertex of the state s is s modulo the number of
+// vertices.
+func (g *Graph) tracePath(predecessor []int32, i int) []Coord {
+	n := 1
+	for j := i; int(predecessor[j]) != j; j = int(predecessor[j]) {
+		n++
+	}
+	path := make([]Coord, n)
+	for j := n - 1; j >= 0; j-- {
+		path[j] = g.coord(i % len(g.cells))
+		i = int(predecessor[i])
+	}
+	return path
+}
+
+// fastestPathBFS visits the vertices with increasing distance from the startVertices
+// until a finishVertex is found. It returns the predecessor of every visited
+// vertex, where -1 means that the vertex hasn't been visited and the startVertices
+// are their own predecessors, the index of the finishVertex or -1 if none was found,
+// and its distance.
+func (g *Graph) fastestPathBFS() ([]int32, int, int) {
+	return g.bfs(g.starts, -1)
+}
+
+// bfs is the BFS of fastestPathBFS, but visits the vertices with increasing distance
+// from the sources until the target is found, or any finishVertex if target is -1.
+func (g *Graph) bfs(sources []int, target int) ([]int32, int, int) {
+	distance, predecessor, _, reached := g.search(sources, target, false)
+	if reached < 0 {
+		return predecessor, -1, 0
+	}
+	return predecessor, reached, int(distance[reached])
+}
+
+// arrival is a state of searchStates that is reached through a portal longer
+// than a step, which is visited when the search gets to its distance.
+type arrival struct {
+	from int32
+	to   int32
+}
+
+// search visits the vertices in layers of increasing distance from the sources until
+// the target is found, or any finishVertex if target is -1. If layers is true the
+// layer of the found vertex is finished before returning. Every step counts as 1
+// and every portal as its cost, see searchStates.
+//
+// It returns the distance and the predecessor of every vertex, where -1 means that the
+// vertex hasn't been visited and the sources are their own predecessors, the visited
+// vertices in order of increasing distance and the index of the found vertex or -1.
+func (g *Graph) search(sources []int, target int, layers bool) ([]int32, []int32, []int32, int) {
+	found := func(x int) bool {
+		return x == target || target < 0 && g.cells[x]&finishFlag != 0
+	}
+	return g.searchStates(len(g.cells), sources, found, g.neighbours, layers)
+}
+
+// neighbours calls yield with every vertex that can be reached from the vertex a and
+// the length of the step there, see successor, until yield returns false.
+func (g *Graph) neighbours(a int, yield func(x int, length int) bool) {
+	for k, n := 0, g.edges(a); k < n; k++ {
+		if x, length, ok := g.successor(a, k); ok && !yield(x, length) {
+			return
+		}
+	}
+}
+
+// searchStates is the layered BFS of search over n states, which are the vertices for
+// search and the pairs of a vertex and the keys held for KeyPath. The search visits the
+// states reached from the sources until a state where found is true, and successors calls
+// yield with every state that can be reached from a state and the length of the step.
+// The steps longer than 1, i.e. the portals, are kept in pending until the search gets
+// to their distance.
+//
+// It returns the same values as search, for the states instead of the vertices.
+func (g *Graph) searchStates(n int, sources []int, found func(s int) bool, successors func(s int, yield func(t int, length int) bool), layers bool) ([]int32, []int32, []int32, int) {
+	distance := make([]int32, n)
+	predecessor := make([]int32, n)
+	for i := range distance {
+		distance[i] = -1
+		predecessor[i] = -1
+	}
+	queue := make([]int32, 0, min(n, len(g.cells)))
+
+	reached := -1
+	for _, s := range sources {
+		distance[s] = 0
+		predecessor[s] = int32(s)
+		queue = append(queue, int32(s))
+		if reached < 0 && found(s) {
+			reached = s
+		}
+	}
+
+	var pending map[int32][]arrival
+	for head, level := 0, int32(0); ; level++ {
+		if head == len(queue) {
+			if len(pending) == 0 {
+				break
+			}
+			// The next layer only has states reached through portals.
+			level = math.MaxInt32
+			for l := range pending {
+				level = min(level, l)
+			}
+		}
+		for _, e := range pending[level] {
+			if distance[e.to] < 0 {
+				distance[e.to] = level
+				predecessor[e.to] = e.from
+				queue = append(queue, e.to)
+				if reached < 0 && found(int(e.to)) {
+					reached = int(e.to)
 				}
 			}
 		}
-	}
-	defer func() {
-		if err := recover(); err != nil {
-			fmt.Println(err)
+		delete(pending, level)
+		if reached >= 0 {
+			return distance, predecessor, queue, reached
+		}
+
+		for end := len(queue); head < end; head++ {
+			s := int(queue[head])
+			successors(s, func(t int, length int) bool {
+				if distance[t] >= 0 {
+					return true
+				}
+				if length > 1 {
+					if at := int(level) + length; at <= math.MaxInt32 {
+						if pending == nil {
+							pending = make(map[int32][]arrival)
+						}
+						pending[int32(at)] = append(pending[int32(at)], arrival{int32(s), int32(t)})
+					}
+					return true
+				}
+				distance[t] = level + 1
+				predecessor[t] = int32(s)
+				queue = append(queue, int32(t))
+				if reached < 0 && found(t) {
+					reached = t
+				}
+				return reached < 0 || layers
+			})
+			if reached >= 0 && !layers {
+				return distance, predecessor, queue, reached
+			}
 		}
-	}()
-	panic("Error: there's no path between the start- and finishVertex")
+	}
+	return distance, predecessor, queue, reached
 }
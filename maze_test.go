@@ -1,6 +1,9 @@
 package maze
 
 import (
+	"errors"
+	"math/bits"
+	"strings"
 	"testing"
 )
 
@@ -10,50 +13,51 @@ func TestNewGraph(t *testing.T) {
 	g_100 := NewGraph(100, 100)
 
 	var tests = []struct {
-		vertices map[string]*vertex
-		exp      int
+		cells []cell
+		exp   int
 	}{
-		{g_1.vertices, 1},
-		{g_5.vertices, 25},
-		{g_100.vertices, 10000},
+		{g_1.cells, 1},
+		{g_5.cells, 25},
+		{g_100.cells, 10000},
 	}
 	for _, e := range tests {
-		res := len(e.vertices)
+		res := len(e.cells)
 		if res != e.exp {
-			t.Errorf("len(Graph.vertices) = %v, expected: %v", res, e.exp)
+			t.Errorf("len(Graph.cells) = %v, expected: %v", res, e.exp)
 		}
 	}
 
-	for _, vert := range g_5.vertices {
-		y, x := coordToInt(vert.key)
-		res := len(vert.neighbours)
+	for i := range g_5.cells {
+		key := g_5.coord(i)
+		y, x := key.Row, key.Col
+		res := passages(&g_5, key)
 		if x == 1 && y == 1 {
 			if res != 2 {
-				t.Errorf("len(%v.neighbours) = %v, expected: 2", vert.key, res)
+				t.Errorf("passages(%v) = %v, expected: 2", key, res)
 			}
 		} else if y == 1 && x == 5 {
 			if res != 2 {
-				t.Errorf("len(%v.neighbours) = %v, expected: 2", vert.key, res)
+				t.Errorf("passages(%v) = %v, expected: 2", key, res)
 			}
 		} else if y == 5 && x == 1 {
 			if res != 2 {
-				t.Errorf("len(%v.neighbours) = %v, expected: 2", vert.key, res)
+				t.Errorf("passages(%v) = %v, expected: 2", key, res)
 			}
 		} else if y == 5 && x == 5 {
 			if res != 2 {
-				t.Errorf("len(%v.neighbours) = %v, expected: 2", vert.key, res)
+				t.Errorf("passages(%v) = %v, expected: 2", key, res)
 			}
 		} else if x == 1 && res != 3 {
-			t.Errorf("len(%v.neighbours) = %v, expected: 3", vert.key, res)
+			t.Errorf("passages(%v) = %v, expected: 3", key, res)
 		} else if x == 5 && res != 3 {
-			t.Errorf("len(%v.neighbours) = %v, expected: 3", vert.key, res)
+			t.Errorf("passages(%v) = %v, expected: 3", key, res)
 		} else if y == 1 && res != 3 {
-			t.Errorf("len(%v.neighbours) = %v, expected: 3", vert.key, res)
+			t.Errorf("passages(%v) = %v, expected: 3", key, res)
 		} else if y == 5 && res != 3 {
-			t.Errorf("len(%v.neighbours) = %v, expected: 3", vert.key, res)
+			t.Errorf("passages(%v) = %v, expected: 3", key, res)
 		} else if x != 1 && x != 5 && y != 1 && y != 5 {
 			if res != 4 {
-				t.Errorf("len(%v.neighbours) = %v, expected: 4", vert.key, res)
+				t.Errorf("passages(%v) = %v, expected: 4", key, res)
 			}
 		}
 	}
@@ -99,24 +103,24 @@ func TestAddObstacle(t *testing.T) {
 		g_5.AddObstacle(i, 3)
 	}
 	for i := 1; i <= 3; i++ {
-		if !g_5.vertices[coordinate(i, 3)].obstacle {
-			t.Errorf("Error: AddObstacle not working properly, vertex.obstacle.")
+		if g_5.cells[g_5.index(Coord{i, 3})]&obstacleFlag == 0 {
+			t.Errorf("Error: AddObstacle not working properly, obstacle flag.")
 		}
-		if len(g_5.vertices[coordinate(i, 3)].neighbours) != 0 {
-			t.Errorf("Error: AddObstacle not working properly, vertex.neighbours.")
+		if passages(&g_5, Coord{i, 3}) != 0 {
+			t.Errorf("Error: AddObstacle not working properly, vertex passages.")
 		}
 		switch i {
 		case 1:
-			if (len(g_5.vertices[coordinate(i, 2)].neighbours) != 2) || (len(g_5.vertices[coordinate(i, 4)].neighbours) != 2) {
-				t.Errorf("Error: AddObstacle not working properly, vertex.neighbours.")
+			if (passages(&g_5, Coord{i, 2}) != 2) || (passages(&g_5, Coord{i, 4}) != 2) {
+				t.Errorf("Error: AddObstacle not working properly, vertex passages.")
 			}
 		default:
-			if (len(g_5.vertices[coordinate(i, 2)].neighbours) != 3) || (len(g_5.vertices[coordinate(i, 4)].neighbours) != 3) {
-				t.Errorf("Error: AddObstacle not working properly, vertex.neighbours.")
+			if (passages(&g_5, Coord{i, 2}) != 3) || (passages(&g_5, Coord{i, 4}) != 3) {
+				t.Errorf("Error: AddObstacle not working properly, vertex passages.")
 			}
 		}
-		if len(g_5.vertices[coordinate(3, 4)].neighbours) != 3 {
-			t.Errorf("Error: AddObstacle not working properly, vertex.neighbours.")
+		if passages(&g_5, Coord{3, 4}) != 3 {
+			t.Errorf("Error: AddObstacle not working properly, vertex passages.")
 		}
 	}
 }
@@ -127,76 +131,76 @@ func TestRemoveObstacle(t *testing.T) {
 	g_3.AddObstacle(1, 2)
 	g_3.RemoveObstacle(2, 2)
 	g_3.RemoveObstacle(3, 3) // RemoveObstacle on vertex that isn't an obstacle
-	if g_3.vertices[coordinate(2, 2)].obstacle {
-		t.Errorf("Error: RemoveObstacle not working properly, vertex.obstacle.")
+	if g_3.cells[g_3.index(Coord{2, 2})]&obstacleFlag != 0 {
+		t.Errorf("Error: RemoveObstacle not working properly, obstacle flag.")
 	}
-	if len(g_3.vertices[coordinate(2, 2)].neighbours) != 3 {
-		t.Errorf("Error: RemoveObstacle not working properly, vertex.neighbours.")
+	if passages(&g_3, Coord{2, 2}) != 3 {
+		t.Errorf("Error: RemoveObstacle not working properly, vertex passages.")
 	}
-	if len(g_3.vertices[coordinate(1, 2)].neighbours) != 0 {
-		t.Errorf("Error: RemoveObstacle not working properly, vertex.neighbours.")
+	if passages(&g_3, Coord{1, 2}) != 0 {
+		t.Errorf("Error: RemoveObstacle not working properly, vertex passages.")
 	}
 }
 
 func TestAddStart(t *testing.T) {
 	g := NewGraph(5, 5)
 	g.AddStart(1, 1)
-	for _, v := range g.vertices {
-		if v.key == coordinate(1, 1) {
-			if !v.startVertex {
-				t.Errorf("Error: AddStart not working properly, vertex.startVertex.")
+	for i, v := range g.cells {
+		if g.coord(i) == (Coord{1, 1}) {
+			if v&startFlag == 0 {
+				t.Errorf("Error: AddStart not working properly, startFlag.")
 			}
 		} else {
-			if v.startVertex {
-				t.Errorf("Error: AddStart not working properly, vertex.startVertex.")
+			if v&startFlag != 0 {
+				t.Errorf("Error: AddStart not working properly, startFlag.")
 			}
 		}
 	}
 	g.AddStart(3, 4)
-	for _, v := range g.vertices {
-		if v.key == coordinate(3, 4) {
-			if !v.startVertex {
-				t.Errorf("Error: AddStart not working properly, vertex.startVertex.")
+	for i, v := range g.cells {
+		if g.coord(i) == (Coord{3, 4}) {
+			if v&startFlag == 0 {
+				t.Errorf("Error: AddStart not working properly, startFlag.")
 			}
 		} else {
-			if v.startVertex {
-				t.Errorf("Error: AddStart not working properly, vertex.startVertex.")
+			if v&startFlag != 0 {
+				t.Errorf("Error: AddStart not working properly, startFlag.")
 			}
 		}
 	}
-	if g.start != coordinate(3, 4) {
-		t.Errorf("Error: AddStart not working properly, g.start")
+	if len(g.starts) != 1 || g.starts[0] != g.index(Coord{3, 4}) {
+		t.Errorf("Error: AddStart not working properly, g.starts")
 	}
 }
 
 func TestAddFinish(t *testing.T) {
 	g := NewGraph(5, 5)
 	g.AddFinish(1, 1)
-	for _, v := range g.vertices {
-		if v.key == coordinate(1, 1) {
-			if !v.finishVertex {
-				t.Errorf("Error: AddFinish not working properly, vertex.finishVertex.")
+	for i, v := range g.cells {
+		if g.coord(i) == (Coord{1, 1}) {
+			if v&finishFlag == 0 {
+				t.Errorf("Error: AddFinish not working properly, finishFlag.")
 			}
 		} else {
-			if v.finishVertex {
-				t.Errorf("Error: AddFinish not working properly, vertex.finishVertex.")
+			if v&finishFlag != 0 {
+				t.Errorf("Error: AddFinish not working properly, finishFlag.")
 			}
 		}
 	}
 	g.AddFinish(3, 4)
-	for _, v := range g.vertices {
-		if v.key == coordinate(3, 4) {
-			if !v.finishVertex {
-				t.Errorf("Error: AddFinish not working properly, vertex.finishVertex.")
+	for i, v := range g.cells {
+		if g.coord(i) == (Coord{3, 4}) {
+			if v&finishFlag == 0 {
+				t.Errorf("Error: AddFinish not working properly, finishFlag.")
 			}
 		} else {
-			if v.finishVertex {
-				t.Errorf("Error: AddFinish not working properly, vertex.finishVertex.")
+			if v&finishFlag != 0 {
+				t.Errorf("Error: AddFinish not working properly, finishFlag.")
 			}
 		}
 	}
-	if g.finish != coordinate(3, 4) {
-		t.Errorf("Error: AddFinish not working properly, g.finish")
+	if len(g.finishes) != 1 || g.finishes[0] != g.index(Coord{3, 4}) {
+		t.Errorf("Error: AddFinish not working properly, g.finishes")
 	}
 }
 
@@ -219,22 +223,22 @@ func TestGetFastestPath(t *testing.T) {
 	g.AddObstacle(1, 2)
 	g.AddObstacle(2, 2)
 	g.AddObstacle(4, 2)
-	exp := []string{
-		"(1,1)",
-		"(2,1)",
-		"(3,1)",
-		"(3,2)",
-		"(3,3)",
-		"(2,3)",
-		"(1,3)",
+	exp := []Coord{
+		{1, 1},
+		{2, 1},
+		{3, 1},
+		{3, 2},
+		{3, 3},
+		{2, 3},
+		{1, 3},
 	}
 	i, s := g.GetFastestPath()
-	if i != 6 || !stringSliceEq(s, exp) {
+	if i != 6 || !coordSliceEq(s, exp) {
 		t.Errorf("g.GetFastestPath() = %v, %v; expected: %v, %v", i, s, 6, exp)
 	}
 }
 
-func stringSliceEq(slice1 []string, slice2 []string) bool {
+func coordSliceEq(slice1 []Coord, slice2 []Coord) bool {
 	if (slice1 == nil) != (slice2 == nil) {
 		return false
 	}
@@ -248,3 +252,124 @@ func stringSliceEq(slice1 []string, slice2 []string) bool {
 	}
 	return true
 }
+
+func TestNew(t *testing.T) {
+	var tests = []struct {
+		height, width int
+		exp           error
+	}{
+		{1, 1, nil},
+		{0, 5, ErrInvalidSize},
+		{5, -1, ErrInvalidSize},
+	}
+	for _, e := range tests {
+		_, err := New(e.height, e.width)
+		if !errors.Is(err, e.exp) {
+			t.Errorf("New(%v, %v) error = %v, expected: %v", e.height, e.width, err, e.exp)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	g, _ := New(3, 3)
+	if err := g.SetStart(Coord{1, 1}); err != nil {
+		t.Errorf("g.SetStart(Coord{1, 1}) = %v, expected: <nil>", err)
+	}
+	if err := g.SetFinish(Coord{3, 3}); err != nil {
+		t.Errorf("g.SetFinish(Coord{3, 3}) = %v, expected: <nil>", err)
+	}
+	if err := g.SetObstacle(Coord{2, 2}); err != nil {
+		t.Errorf("g.SetObstacle(Coord{2, 2}) = %v, expected: <nil>", err)
+	}
+
+	var tests = []struct {
+		name string
+		err  error
+		exp  error
+	}{
+		{"SetObstacle(0, 1)", g.SetObstacle(Coord{0, 1}), ErrOutOfBounds},
+		{"SetObstacle(1, 4)", g.SetObstacle(Coord{1, 4}), ErrOutOfBounds},
+		{"SetObstacle(1, 1)", g.SetObstacle(Coord{1, 1}), ErrIsStart},
+		{"SetObstacle(3, 3)", g.SetObstacle(Coord{3, 3}), ErrIsFinish},
+		{"ClearObstacle(4, 4)", g.ClearObstacle(Coord{4, 4}), ErrOutOfBounds},
+		{"SetStart(2, 2)", g.SetStart(Coord{2, 2}), ErrIsObstacle},
+		{"SetStart(3, 3)", g.SetStart(Coord{3, 3}), ErrIsFinish},
+		{"SetStart(-1, 2)", g.SetStart(Coord{-1, 2}), ErrOutOfBounds},
+		{"SetFinish(2, 2)", g.SetFinish(Coord{2, 2}), ErrIsObstacle},
+		{"SetFinish(1, 1)", g.SetFinish(Coord{1, 1}), ErrIsStart},
+		{"SetFinish(2, 9)", g.SetFinish(Coord{2, 9}), ErrOutOfBounds},
+	}
+	for _, e := range tests {
+		if !errors.Is(e.err, e.exp) {
+			t.Errorf("g.%v = %v, expected: %v", e.name, e.err, e.exp)
+		}
+	}
+
+	g.AddObstacle(1, 2)
+	g.AddObstacle(2, 1)
+	if _, _, err := g.FastestPath(); !errors.Is(err, ErrNoPath) {
+		t.Errorf("g.FastestPath() error = %v, expected: %v", err, ErrNoPath)
+	}
+	if i, s := g.GetFastestPath(); i != 0 || s != nil {
+		t.Errorf("g.GetFastestPath() = %v, %v; expected: 0, []", i, s)
+	}
+
+	empty, _ := New(2, 2)
+	if _, _, err := empty.FastestPath(); !errors.Is(err, ErrNoPath) {
+		t.Errorf("empty.FastestPath() error = %v, expected: %v", err, ErrNoPath)
+	}
+}
+
+func TestCoord(t *testing.T) {
+	var tests = []struct {
+		c   Coord
+		exp string
+	}{
+		{Coord{1, 1}, "(1,1)"},
+		{Coord{12, 3}, "(12,3)"},
+		{Coord{100, 45}, "(100,45)"},
+	}
+	for _, e := range tests {
+		res := e.c.String()
+		if res != e.exp {
+			t.Errorf("%#v.String() = %v, expected: %v", e.c, res, e.exp)
+		}
+	}
+
+	g := NewGraph(12, 1)
+	g.AddStart(1, 1)
+	g.AddFinish(12, 1)
+	res := g.StringFastestPath()
+	if strings.Count(res, "( p )") != 10 || strings.Contains(res, "(11,1)") || !strings.HasSuffix(res, "distance =11") {
+		t.Errorf("g.StringFastestPath() = %v, expected the path (2,1)...(11,1) marked", res)
+	}
+}
+
+// passages returns the number of edges of the vertex c.
+func passages(g *Graph, c Coord) int {
+	return bits.OnesCount8(uint8(g.cells[g.index(c)]))
+}
+
+func TestLargeGraph(t *testing.T) {
+	g, err := New(1000, 1000)
+	if err != nil {
+		t.Fatalf("New(1000, 1000) error = %v, expected: <nil>", err)
+	}
+	g.AddStart(1, 1)
+	g.AddFinish(1000, 1000)
+	for i := 1; i < 1000; i++ {
+		g.AddObstacle(i, 500)
+	}
+	i, s := g.GetFastestPath()
+	if len(s) == 0 {
+		t.Fatalf("g.GetFastestPath() = %v, %v; expected a path", i, s)
+	}
+	if i != 1998 || len(s) != 1999 || s[0] != (Coord{1, 1}) || s[1998] != (Coord{1000, 1000}) {
+		t.Errorf("g.GetFastestPath() = %v, [%v ... %v]; expected: 1998, [(1,1) ... (1000,1000)]", i, s[0], s[len(s)-1])
+	}
+	for _, c := range s {
+		if c.Col == 500 && c.Row != 1000 {
+			t.Errorf("g.GetFastestPath() passes the obstacle %v", c)
+		}
+	}
+}
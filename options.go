@@ -0,0 +1,81 @@
+package maze
+
+import "fmt"
+
+// Option changes how New creates a graph.
+type Option func(*Graph)
+
+// Wrap is the directions a graph created WithWrap wraps around in.
+type Wrap int
+
+const (
+	// WrapHorizontal connects the left and right edges of the graph,
+	// i.e. (y,1) and (y,width) are adjacent.
+	WrapHorizontal Wrap = 1 << iota
+
+	// WrapVertical connects the top and bottom edges of the graph,
+	// i.e. (1,x) and (height,x) are adjacent.
+	WrapVertical
+
+	// WrapBoth connects both pairs of edges, so the graph is a torus.
+	WrapBoth = WrapHorizontal | WrapVertical
+)
+
+// CornerPolicy decides when a diagonal edge can pass the corners of the two
+// orthogonal vertices it goes between, e.g. the edge from (2,2) to (1,3) passes
+// the vertices (1,2) and (2,3).
+type CornerPolicy int
+
+const (
+	// CornersAllowed allows diagonal edges past any obstacles.
+	CornersAllowed CornerPolicy = iota
+
+	// CornersBlockedByEither removes a diagonal edge when either of the
+	// vertices it passes is an obstacle, i.e. corners can't be cut.
+	CornersBlockedByEither
+
+	// CornersBlockedByBoth removes a diagonal edge when both of the vertices
+	// it passes are obstacles, i.e. it's not possible to squeeze between them.
+	CornersBlockedByBoth
+)
+
+// WithDiagonals creates a graph with 8-connectivity, i.e. where every vertex also
+// has edges to the diagonal vertices (y-1,x+1), (y+1,x+1), (y+1,x-1) and (y-1,x-1).
+// The policy decides when a diagonal edge can pass obstacles.
+//
+// Every edge has the distance 1, so Chebyshev is the admissible Heuristic for AStar.
+// WithDiagonals replaces WithHex, if both are given the last one is used.
+func WithDiagonals(policy CornerPolicy) Option {
+	return func(g *Graph) {
+		g.dirs = orthogonal | 0xf0
+		g.corners = policy
+		g.hex = false
+	}
+}
+
+// WithWrap creates a graph that wraps around, i.e. leaving the graph on one
+// edge brings you back on the opposite edge, horizontally, vertically or both.
+// Obstacles, walls and every path finder follow the wrapped edges, and they're
+// displayed as openings in the border.
+//
+// A wrapping direction needs at least 3 vertices, so New returns an error wrapping
+// ErrInvalidSize for smaller graphs, and an error wrapping ErrUnsupported for
+// hex grids. The heuristics other than Zero aren't admissible for AStar, since
+// the shortest path can go across the edges.
+func WithWrap(w Wrap) Option {
+	return func(g *Graph) {
+		g.wrap = w
+	}
+}
+
+// checkOptions returns an error if the options given to New can't be combined
+// or don't fit the size of the graph.
+func (g *Graph) checkOptions() error {
+	if g.wrap != 0 && g.hex {
+		return fmt.Errorf("%w: hex grids can't wrap around", ErrUnsupported)
+	}
+	if g.wrap&WrapHorizontal != 0 && g.width < 3 || g.wrap&WrapVertical != 0 && g.height < 3 {
+		return fmt.Errorf("%w: %dx%d is too small to wrap around", ErrInvalidSize, g.height, g.width)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package maze
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithDiagonals(t *testing.T) {
+	g, _ := New(3, 3, WithDiagonals(CornersAllowed))
+	var tests = []struct {
+		c   Coord
+		exp int
+	}{
+		{Coord{2, 2}, 8},
+		{Coord{1, 1}, 3},
+		{Coord{1, 2}, 5},
+	}
+	for _, e := range tests {
+		if res := passages(g, e.c); res != e.exp {
+			t.Errorf("passages(%v) = %v, expected: %v", e.c, res, e.exp)
+		}
+	}
+
+	g.AddStart(1, 1)
+	g.AddFinish(3, 3)
+	exp := []Coord{{1, 1}, {2, 2}, {3, 3}}
+	if i, s := g.GetFastestPath(); i != 2 || !coordSliceEq(s, exp) {
+		t.Errorf("g.GetFastestPath() = %v, %v; expected: %v, %v", i, s, 2, exp)
+	}
+	if i, _, _, _ := g.AStar(Chebyshev); i != 2 {
+		t.Errorf("g.AStar(Chebyshev) distance = %v, expected: 2", i)
+	}
+	expString := "\n.-------.-------.-------.\n| ( s )   (1,2)   (1,3) |\n:       \\       +       +\n| (2,1)   ( p )   (2,3) |\n:       +       \\       +\n| (3,1)   (3,2)   ( f ) |\n'-------'-------'-------'\n\ndistance =2"
+	if res := g.StringFastestPath(); res != expString {
+		t.Errorf("g.StringFastestPath() = %v, expected: %v", res, expString)
+	}
+
+	if err := g.AddWall(Coord{2, 2}, Coord{3, 3}); err != nil || !g.HasWall(Coord{3, 3}, Coord{2, 2}) {
+		t.Errorf("g.AddWall((2,2), (3,3)) = %v, expected a diagonal wall", err)
+	}
+	if err := g.AddWall(Coord{1, 1}, Coord{3, 3}); !errors.Is(err, ErrNotAdjacent) {
+		t.Errorf("g.AddWall((1,1), (3,3)) = %v, expected: %v", err, ErrNotAdjacent)
+	}
+}
+
+func TestCornerPolicy(t *testing.T) {
+	var tests = []struct {
+		policy    CornerPolicy
+		obstacles []Coord
+		exp       int
+	}{
+		{CornersAllowed, []Coord{{1, 1}}, 1},
+		{CornersBlockedByEither, []Coord{{1, 1}}, 2},
+		{CornersBlockedByBoth, []Coord{{1, 1}}, 1},
+		{CornersAllowed, []Coord{{1, 1}, {2, 2}}, 1},
+		{CornersBlockedByEither, []Coord{{1, 1}, {2, 2}}, 0},
+		{CornersBlockedByBoth, []Coord{{1, 1}, {2, 2}}, 0},
+	}
+	for _, e := range tests {
+		g, _ := New(2, 2, WithDiagonals(e.policy))
+		g.AddStart(2, 1)
+		g.AddFinish(1, 2)
+		for _, c := range e.obstacles {
+			g.SetObstacle(c)
+		}
+		if i, _ := g.GetFastestPath(); i != e.exp {
+			t.Errorf("policy %v with obstacles %v: g.GetFastestPath() = %v, expected: %v", e.policy, e.obstacles, i, e.exp)
+		}
+		for _, c := range e.obstacles {
+			g.ClearObstacle(c)
+		}
+		if i, _ := g.GetFastestPath(); i != 1 {
+			t.Errorf("policy %v after ClearObstacle: g.GetFastestPath() = %v, expected: 1", e.policy, i)
+		}
+	}
+}
+
+func TestWithWrap(t *testing.T) {
+	var errTests = []struct {
+		h, w    int
+		options []Option
+		exp     error
+	}{
+		{1, 2, []Option{WithWrap(WrapHorizontal)}, ErrInvalidSize},
+		{2, 5, []Option{WithWrap(WrapVertical)}, ErrInvalidSize},
+		{3, 3, []Option{WithHex(), WithWrap(WrapBoth)}, ErrUnsupported},
+		{2, 5, []Option{WithWrap(WrapHorizontal)}, nil},
+	}
+	for _, e := range errTests {
+		if _, err := New(e.h, e.w, e.options...); !errors.Is(err, e.exp) {
+			t.Errorf("New(%v, %v, ...) = %v, expected: %v", e.h, e.w, err, e.exp)
+		}
+	}
+
+	g, _ := New(1, 5, WithWrap(WrapHorizontal))
+	g.AddStart(1, 1)
+	g.AddFinish(1, 5)
+	exp := []Coord{{1, 1}, {1, 5}}
+	if i, s := g.GetFastestPath(); i != 1 || !coordSliceEq(s, exp) {
+		t.Errorf("g.GetFastestPath() = %v, %v; expected: %v, %v", i, s, 1, exp)
+	}
+	expString := "\n.-------.-------.-------.-------.-------.\n  ( s )   (1,2)   (1,3)   (1,4)   ( f )  \n'-------'-------'-------'-------'-------'\n\ndistance =1"
+	if res := g.StringFastestPath(); res != expString {
+		t.Errorf("g.StringFastestPath() = %v, expected: %v", res, expString)
+	}
+	g.SetObstacle(Coord{1, 2})
+	if res := passages(g, Coord{1, 1}); res != 1 {
+		t.Errorf("passages((1,1)) = %v, expected: 1", res)
+	}
+	g.ClearObstacle(Coord{1, 2})
+	if res := passages(g, Coord{1, 1}); res != 2 {
+		t.Errorf("passages((1,1)) = %v, expected: 2", res)
+	}
+	if err := g.AddWall(Coord{1, 5}, Coord{1, 1}); err != nil {
+		t.Errorf("g.AddWall((1,5), (1,1)) = %v, expected: <nil>", err)
+	}
+	if i, _ := g.GetFastestPath(); i != 4 {
+		t.Errorf("g.GetFastestPath() = %v, expected: 4", i)
+	}
+
+	g, _ = New(3, 3, WithWrap(WrapBoth))
+	if res := passages(g, Coord{1, 1}); res != 4 {
+		t.Errorf("passages((1,1)) = %v, expected: 4", res)
+	}
+	g.AddStart(1, 1)
+	g.AddFinish(3, 3)
+	if i, _ := g.GetFastestPath(); i != 2 {
+		t.Errorf("g.GetFastestPath() = %v, expected: 2", i)
+	}
+	if n := g.CountShortestPaths(); n.Int64() != 2 {
+		t.Errorf("g.CountShortestPaths() = %v, expected: 2", n)
+	}
+}
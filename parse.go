@@ -0,0 +1,290 @@
+package maze
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The states of an edge in the ASCII representation, see edgeLabel, where forward
+// is a one-way passage to the south or east and backward to the north or west.
+const (
+	edgeOpen = iota
+	edgeClosed
+	edgeForward
+	edgeBackward
+)
+
+// horizontalSlots and verticalSlots contain the state of every way an edge can be
+// displayed in the rows between the vertices and the rows of the vertices.
+var (
+	horizontalSlots = map[string]int{"       ": edgeOpen, "-------": edgeClosed, "   v   ": edgeForward, "   ^   ": edgeBackward}
+	verticalSlots   = map[byte]int{' ': edgeOpen, '|': edgeClosed, '>': edgeForward, '<': edgeBackward}
+)
+
+// The function ParseASCII reads a graph from the ASCII representation returned by String,
+// and rebuilds its walls, obstacles, one-way passages, start- and finishVertices and
+// whether it wraps around, so ParseASCII of g.String() is displayed exactly like g.
+// The output of StringFastestPath and the other path methods can also be read, the
+// marked vertices are read as ordinary vertices and the text after the graph is ignored.
+// Every other vertex must be labelled with its own coordinates.
+//
+// An edge that is closed between two vertices is read as a wall, and a vertex that is
+// closed on every side as an obstacle, since they're displayed the same. The vertex of
+// a 1x1 graph has no edges, so it's always read as an open vertex, like the one of
+// New(1, 1), even if it was an obstacle. The costs,
+// portals, keys, doors and schedules aren't part of the representation, and neither
+// are the diagonal edges or hex grids, so they aren't read.
+//
+// ParseASCII returns an error wrapping ErrInvalidFormat with the line and column of
+// the first character that doesn't fit the representation, e.g. a label that isn't the
+// coordinates of its vertex or a mark, or the error of the reader.
+func ParseASCII(r io.Reader) (*Graph, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Blank lines before the graph are skipped, like the one StringFastestPath starts with.
+	line := 0
+	for line < len(lines) && strings.TrimSpace(lines[line]) == "" {
+		line++
+	}
+	if line == len(lines) {
+		return nil, formatError(line, 0, "expected the top border \".-------.\"")
+	}
+	top := lines[line]
+	if len(top) < 9 || (len(top)-1)%8 != 0 || top[0] != '.' {
+		return nil, formatError(line, 0, "expected the top border \".-------.\"")
+	}
+	width := (len(top) - 1) / 8
+	border, err := parseSlots(top, line, width, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	// vertical contains the edges below every row and horizontal the edges east of
+	// every vertex, where the edges below the last row and east of the last column
+	// are the ones that wrap around.
+	var vertical, horizontal [][]int
+	var starts, finishes []int
+	for {
+		line++
+		if line == len(lines) {
+			return nil, formatError(line, 0, "expected a row of vertices")
+		}
+		// The labels are wider than the edges for coordinates with more than one digit,
+		// so the row is read label by label, and the spaces at the end of the row that
+		// text editors often remove are read as spaces.
+		row := lines[line]
+		at := func(col int) byte {
+			if col < len(row) {
+				return row[col]
+			}
+			return ' '
+		}
+		edges := make([]int, width)
+		left, found := verticalSlots[at(0)]
+		if !found {
+			return nil, formatError(line, 0, "expected one of \" |<>\", found %q", at(0))
+		}
+		col := 1
+		for j := 0; j < width; j++ {
+			end := strings.IndexByte(row[min(col, len(row)):], ')')
+			if at(col) != ' ' || at(col+1) != '(' || end < 0 {
+				return nil, formatError(line, col, "expected a vertex like \" (1,1) \"")
+			}
+			label := row[col+1 : col+end+1]
+			switch {
+			case label == "( s )":
+				starts = append(starts, len(horizontal)*width+j)
+			case label == "( f )":
+				finishes = append(finishes, len(horizontal)*width+j)
+			case label != (Coord{len(horizontal) + 1, j + 1}).String() && !isMarkLabel(label):
+				return nil, formatError(line, col+1, "expected the vertex %v or a mark like \"( p )\", found %q", Coord{len(horizontal) + 1, j + 1}, label)
+			}
+			col += end + 1
+			if at(col) != ' ' {
+				return nil, formatError(line, col, "expected ' ', found %q", at(col))
+			}
+			edge, found := verticalSlots[at(col+1)]
+			if !found {
+				return nil, formatError(line, col+1, "expected one of \" |<>\", found %q", at(col+1))
+			}
+			edges[j] = edge
+			col += 2
+		}
+		if col < len(row) {
+			return nil, formatError(line, col, "expected the end of the row, found %q", row[col:])
+		}
+		if edges[width-1] != left {
+			return nil, formatError(line, col-1, "expected the right border to match the left border %q", at(0))
+		}
+		horizontal = append(horizontal, edges)
+
+		line++
+		if line == len(lines) {
+			return nil, formatError(line, 0, "expected the bottom border \"'-------'\"")
+		}
+		row = lines[line]
+		if strings.HasPrefix(row, "'") {
+			slots, err := parseSlots(row, line, width, "'")
+			if err != nil {
+				return nil, err
+			}
+			for j := range slots {
+				if slots[j] != border[j] {
+					return nil, formatError(line, 1+8*j, "expected the bottom border to match the top border %q", top[1+8*j:8+8*j])
+				}
+			}
+			vertical = append(vertical, border)
+			break
+		}
+		if !strings.HasPrefix(row, ":") {
+			return nil, formatError(line, 0, "expected ':' or '\\'' to start the row")
+		}
+		slots, err := parseSlots(row, line, width, "+\\/X")
+		if err != nil {
+			return nil, err
+		}
+		vertical = append(vertical, slots)
+	}
+
+	height := len(horizontal)
+	var wrap Wrap
+	for j := range border {
+		if border[j] != edgeClosed {
+			wrap |= WrapVertical
+		}
+	}
+	for y := range horizontal {
+		if horizontal[y][width-1] != edgeClosed {
+			wrap |= WrapHorizontal
+		}
+	}
+	var options []Option
+	if wrap != 0 {
+		options = append(options, WithWrap(wrap))
+	}
+	g, err := New(height, width, options...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidFormat, line+1, err)
+	}
+
+	// An edge is an index of a vertex, a direction and the state of the edge.
+	var edges [][3]int
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := y*width + x
+			if _, ok := g.adjacent(i, 2); ok {
+				edges = append(edges, [3]int{i, 2, vertical[y][x]})
+			}
+			if _, ok := g.adjacent(i, 1); ok {
+				edges = append(edges, [3]int{i, 1, horizontal[y][x]})
+			}
+		}
+	}
+	// A vertex without edges, i.e. the one of a 1x1 graph, is never enclosed.
+	enclosed := make([]bool, len(g.cells))
+	for _, e := range edges {
+		j, _ := g.adjacent(e[0], e[1])
+		enclosed[e[0]], enclosed[j] = true, true
+	}
+	for _, e := range edges {
+		if e[2] != edgeClosed {
+			j, _ := g.adjacent(e[0], e[1])
+			enclosed[e[0]], enclosed[j] = false, false
+		}
+	}
+	for _, i := range append(starts, finishes...) {
+		enclosed[i] = false
+	}
+
+	for _, e := range edges {
+		i, d := e[0], e[1]
+		j, _ := g.adjacent(i, d)
+		if enclosed[i] || enclosed[j] {
+			continue
+		}
+		switch e[2] {
+		case edgeClosed:
+			g.cells[i] |= wall(d)
+			g.cells[j] |= wall(opposite(d))
+		case edgeForward:
+			g.cells[j] |= oneWay(opposite(d))
+		case edgeBackward:
+			g.cells[i] |= oneWay(d)
+		}
+	}
+	for i := range g.cells {
+		if enclosed[i] {
+			g.cells[i] |= obstacleFlag
+		}
+	}
+	for _, i := range starts {
+		g.cells[i] |= startFlag
+	}
+	for _, i := range finishes {
+		g.cells[i] |= finishFlag
+	}
+	g.starts, g.finishes = append(g.starts, starts...), append(g.finishes, finishes...)
+	g.refreshAll()
+	return g, nil
+}
+
+// parseSlots returns the states of the edges in a border or a row between the vertices,
+// which starts with a separator and has a separator, one of seps, after every edge.
+func parseSlots(row string, line int, width int, seps string) ([]int, error) {
+	if len(row) != 1+8*width {
+		return nil, formatError(line, min(len(row), 1+8*width), "expected %d vertices in the row", width)
+	}
+	slots := make([]int, width)
+	for j := range slots {
+		col := 1 + 8*j
+		slot, found := horizontalSlots[row[col:col+7]]
+		if !found {
+			return nil, formatError(line, col, "expected an edge like \"-------\", found %q", row[col:col+7])
+		}
+		if !strings.ContainsRune(seps, rune(row[col+7])) {
+			return nil, formatError(line, col+7, "expected one of %q, found %q", seps, row[col+7])
+		}
+		slots[j] = slot
+	}
+	return slots, nil
+}
+
+// markChars contains the characters of the marks that the path methods display in
+// the vertices, see markLabel.
+const markChars = "abcdefghijklmnopqrstuvwxyz0123456789D>^v~"
+
+// isMarkLabel reports whether the label is a mark of 1-3 characters displayed like
+// markLabel does, e.g. "( p )", "(pk3)" or "(~34)".
+func isMarkLabel(label string) bool {
+	if len(label) != 5 || label[0] != '(' || label[4] != ')' {
+		return false
+	}
+	mark := label[1:4]
+	switch {
+	case mark[0] == ' ' && mark[2] == ' ':
+		mark = mark[1:2]
+	case mark[2] == ' ':
+		mark = mark[:2]
+	}
+	for k := 0; k < len(mark); k++ {
+		if !strings.ContainsRune(markChars, rune(mark[k])) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatError returns an error wrapping ErrInvalidFormat at the 0-based line and
+// column, which are displayed 1-based.
+func formatError(line int, col int, format string, args ...any) error {
+	return fmt.Errorf("%w: line %d, column %d: %s", ErrInvalidFormat, line+1, col+1, fmt.Sprintf(format, args...))
+}
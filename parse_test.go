@@ -0,0 +1,105 @@
+package maze
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseASCII(t *testing.T) {
+	g, _ := New(4, 5)
+	g.AddStart(1, 1)
+	g.AddFinish(4, 5)
+	g.AddObstacle(2, 2)
+	g.AddWall(Coord{1, 3}, Coord{1, 4})
+	g.AddWall(Coord{3, 5}, Coord{4, 5})
+	g.SetOneWay(Coord{4, 1}, Coord{4, 2})
+	g.SetOneWay(Coord{3, 3}, Coord{2, 3})
+	maze, _ := New(8, 9)
+	maze.Generate(Kruskal, 4)
+	maze.AddStart(1, 1)
+	maze.AddFinish(8, 9)
+	torus, _ := New(3, 4, WithWrap(WrapBoth))
+	torus.AddWall(Coord{1, 1}, Coord{1, 4})
+	torus.SetOneWay(Coord{3, 2}, Coord{1, 2})
+	torus.AddStart(2, 2)
+	dungeon, _ := New(20, 30)
+	dungeon.GenerateDungeon(DefaultDungeon, 2)
+	single, _ := New(1, 1)
+
+	for _, graph := range []*Graph{g, maze, torus, dungeon, single} {
+		parsed, err := ParseASCII(strings.NewReader(graph.String()))
+		if err != nil {
+			t.Fatalf("ParseASCII(%v) error = %v, expected: <nil>", graph, err)
+		}
+		if parsed.String() != graph.String() {
+			t.Errorf("ParseASCII() = \n%v, expected: \n%v", parsed, graph)
+		}
+		if parsed.wrap != graph.wrap || parsed.Starts() == nil && graph.Starts() != nil {
+			t.Errorf("ParseASCII() wrap = %v, starts = %v; expected: %v, %v", parsed.wrap, parsed.Starts(), graph.wrap, graph.Starts())
+		}
+	}
+
+	parsed, _ := ParseASCII(strings.NewReader(g.String()))
+	if parsed.cells[parsed.index(Coord{2, 2})]&obstacleFlag == 0 || !parsed.HasWall(Coord{1, 3}, Coord{1, 4}) || !parsed.IsOneWay(Coord{4, 1}, Coord{4, 2}) {
+		t.Errorf("ParseASCII() didn't read the obstacle, wall and one-way passage:\n%v", parsed)
+	}
+	parsed, err := ParseASCII(strings.NewReader(maze.StringFastestPath()))
+	if err != nil || parsed.String() != maze.String() {
+		t.Errorf("ParseASCII(maze.StringFastestPath()) = %v, %v; expected: \n%v", parsed, err, maze)
+	}
+	exp, _, _ := maze.FastestPath()
+	if distance, _, _ := parsed.FastestPath(); distance != exp {
+		t.Errorf("parsed.FastestPath() = %v, expected: %v", distance, exp)
+	}
+	// The marks of the path methods are read as ordinary vertices.
+	keys, _ := New(1, 5)
+	keys.AddStart(1, 2)
+	keys.AddFinish(1, 5)
+	keys.SetKey(Coord{1, 1}, 3)
+	keys.SetDoor(Coord{1, 4}, 3)
+	keys.AddOneWayPortal(Coord{1, 3}, Coord{1, 1}, 1)
+	long, _ := New(1, 1100)
+	long.AddStart(1, 1)
+	long.AddFinish(1, 1100)
+	for _, res := range []string{keys.StringKeyPath(), long.StringTimedPath(2000)} {
+		if _, err := ParseASCII(strings.NewReader(res)); err != nil {
+			t.Errorf("ParseASCII(%q) error = %v, expected: <nil>", res[:50], err)
+		}
+	}
+
+	trimmed := strings.ReplaceAll(torus.String(), " \n", "\n")
+	if parsed, err := ParseASCII(strings.NewReader(trimmed)); err != nil || parsed.String() != torus.String() {
+		t.Errorf("ParseASCII() without trailing spaces = %v, %v; expected: \n%v", parsed, err, torus)
+	}
+
+	// The obstacle of a 1x1 graph is displayed like an open vertex and read as one.
+	single.AddObstacle(1, 1)
+	if parsed, err := ParseASCII(strings.NewReader(single.String())); err != nil || parsed.cells[0]&obstacleFlag != 0 || parsed.String() != single.String() {
+		t.Errorf("ParseASCII() of a 1x1 obstacle = %v, %v; expected an open vertex", parsed, err)
+	}
+
+	var tests = []struct {
+		input string
+		exp   string
+	}{
+		{"", "line 1, column 1"},
+		{"\n.-------.\n| (1,1) |\n", "line 4, column 1"},
+		{".-------.\n| (1,1) |\n'-------.\n", "line 3, column 9"},
+		{".-------.-------.\n| (1,1) # (1,2) |\n'-------'-------'\n", "line 2, column 9"},
+		{".-------.\n| (1,1) |\n:---+---+\n", "line 3, column 2"},
+		{".-------.\n| (1,1)  \n'-------'\n", "line 2, column 9"},
+		{".-------.\n| [1,1] |\n'-------'\n", "line 2, column 2"},
+		{".-------.\n| (2,1) |\n'-------'\n", "line 2, column 3"},
+		{".-------.-------.\n| (1,1)   (1,1) |\n'-------'-------'\n", "line 2, column 11"},
+		{".-------.\n| ( x!) |\n'-------'\n", "line 2, column 3"},
+		{".-------.\n| (  p) |\n'-------'\n", "line 2, column 3"},
+		{".-------.\n| (PPP) |\n'-------'\n", "line 2, column 3"},
+	}
+	for _, e := range tests {
+		_, err := ParseASCII(strings.NewReader(e.input))
+		if !errors.Is(err, ErrInvalidFormat) || !strings.Contains(err.Error(), e.exp) {
+			t.Errorf("ParseASCII(%q) = %v, expected: %v at %v", e.input, err, ErrInvalidFormat, e.exp)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package maze
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// portal is a one-way edge to the vertex with index to, which doesn't have to
+// be adjacent, with the length or cost of taking it.
+type portal struct {
+	to   int
+	cost int
+}
+
+// The method AddPortal links the vertices a and b with a portal in both directions,
+// with the cost of taking it. The vertices don't have to be adjacent, and the portal
+// counts as cost steps in the distance of GetFastestPath and as cost in CheapestPath,
+// so a portal with cost 1 is like an edge to an adjacent vertex.
+//
+// Adding a portal that already exists changes its cost. AddPortal returns an error
+// if a or b is outside of the graph or is an obstacle, if a and b are the same
+// vertex or if cost < 1.
+func (g *Graph) AddPortal(a Coord, b Coord, cost int) error {
+	if err := g.AddOneWayPortal(a, b, cost); err != nil {
+		return err
+	}
+	return g.AddOneWayPortal(b, a, cost)
+}
+
+// The method AddOneWayPortal links the vertices from and to with a portal that can only
+// be taken from from to to, otherwise it's like AddPortal.
+func (g *Graph) AddOneWayPortal(from Coord, to Coord, cost int) error {
+	for _, c := range []Coord{from, to} {
+		if err := g.checkBounds(c); err != nil {
+			return err
+		}
+		if g.cells[g.index(c)]&obstacleFlag != 0 {
+			return fmt.Errorf("%w: %v", ErrIsObstacle, c)
+		}
+	}
+	if from == to {
+		return fmt.Errorf("%w: a portal can't link %v to itself", ErrNotAdjacent, from)
+	}
+	if cost < 1 || cost > math.MaxInt32 {
+		return fmt.Errorf("%w: portal from %v to %v has cost %d", ErrInvalidCost, from, to, cost)
+	}
+	if g.portals == nil {
+		g.portals = make(map[int][]portal)
+	}
+	i, j := g.index(from), g.index(to)
+	for k, p := range g.portals[i] {
+		if p.to == j {
+			g.portals[i][k].cost = cost
+			return nil
+		}
+	}
+	g.portals[i] = append(g.portals[i], portal{j, cost})
+	return nil
+}
+
+// The method RemovePortal removes the portals between the vertices a and b in both
+// directions. Removing a portal that doesn't exist does nothing.
+//
+// RemovePortal returns an error if a or b is outside of the graph.
+func (g *Graph) RemovePortal(a Coord, b Coord) error {
+	if err := g.checkBounds(a); err != nil {
+		return err
+	}
+	if err := g.checkBounds(b); err != nil {
+		return err
+	}
+	i, j := g.index(a), g.index(b)
+	for _, e := range [][2]int{{i, j}, {j, i}} {
+		portals := g.portals[e[0]][:0]
+		for _, p := range g.portals[e[0]] {
+			if p.to != e[1] {
+				portals = append(portals, p)
+			}
+		}
+		if len(portals) == 0 {
+			delete(g.portals, e[0])
+		} else {
+			g.portals[e[0]] = portals
+		}
+	}
+	return nil
+}
+
+// portalMarks returns the marks with the ends of every portal marked with a label,
+// so a path can be followed through the portals. The vertices that are linked by
+// portals get the same label, so both ends of a portal always have the same label,
+// also when a vertex has several portals. The labels are a, b, ..., z, aa, ab, ..., zz
+// in the order of the vertices, see portalLabel, and the entrance of a one-way portal
+// also gets a ">". A mark that is already there, like the "p" of a path, is kept in
+// front of the label if there's room for both.
+func (g *Graph) portalMarks(marks map[int]string) map[int]string {
+	if len(g.portals) == 0 {
+		return marks
+	}
+
+	// parent joins the vertices linked by portals into groups, where the smallest
+	// vertex of a group is its root.
+	parent := make(map[int]int)
+	find := func(i int) int {
+		for {
+			p, found := parent[i]
+			if !found || p == i {
+				return i
+			}
+			i = p
+		}
+	}
+	var ends []int
+	for i, portals := range g.portals {
+		ends = append(ends, i)
+		for _, p := range portals {
+			ends = append(ends, p.to)
+			if a, b := find(i), find(p.to); a != b {
+				parent[max(a, b)] = min(a, b)
+			}
+		}
+	}
+	sort.Ints(ends)
+
+	result := make(map[int]string, len(marks)+len(ends))
+	for i, mark := range marks {
+		result[i] = mark
+	}
+	labels := make(map[int]string)
+	for k, i := range ends {
+		if k > 0 && ends[k-1] == i {
+			continue
+		}
+		root := find(i)
+		text, found := labels[root]
+		if !found {
+			text = portalLabel(len(labels))
+			labels[root] = text
+		}
+		for _, p := range g.portals[i] {
+			if !g.hasPortal(p.to, i) {
+				text += ">"
+				break
+			}
+		}
+		if len(marks[i]+text) <= 3 {
+			text = marks[i] + text
+		}
+		result[i] = text
+	}
+	return result
+}
+
+// portalLabel returns the k:th label of portalMarks, i.e. a-z for the first 26 groups
+// of portals and aa-zz for the next 676. The labels start over after that, since a longer label
+// doesn't fit in a vertex.
+func portalLabel(k int) string {
+	k %= 26 + 26*26
+	if k < 26 {
+		return string(rune('a' + k))
+	}
+	k -= 26
+	return string([]byte{byte('a' + k/26), byte('a' + k%26)})
+}
+
+// hasPortal reports whether there's a portal from the vertex i to the vertex j.
+func (g *Graph) hasPortal(i int, j int) bool {
+	for _, p := range g.portals[i] {
+		if p.to == j {
+			return true
+		}
+	}
+	return false
+}
+
+// successor returns the vertex at the other end of the k-th possible edge from the
+// vertex a and the length of the edge, or false if there's no such edge. The first
+// len(directions) edges are the steps to the adjacent vertices and the rest are the
+// portals from a, see edges.
+func (g *Graph) successor(a int, k int) (int, int, bool) {
+	if k < len(directions) {
+		if g.cells[a]&passage(k) == 0 {
+			return 0, 0, false
+		}
+		return g.step(a, k), 1, true
+	}
+	p := g.portals[a][k-len(directions)]
+	if g.cells[p.to]&obstacleFlag != 0 {
+		return 0, 0, false
+	}
+	return p.to, p.cost, true
+}
+
+// edges returns the number of possible edges from the vertex a, see successor.
+func (g *Graph) edges(a int) int {
+	return len(directions) + len(g.portals[a])
+}
@@ -0,0 +1,173 @@
+package maze
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAddPortal(t *testing.T) {
+	g, _ := New(1, 6)
+	g.AddStart(1, 1)
+	g.AddFinish(1, 6)
+	var tests = []struct {
+		cost int
+		exp  int
+		path []Coord
+	}{
+		{1, 3, []Coord{{1, 1}, {1, 2}, {1, 5}, {1, 6}}},
+		{2, 4, []Coord{{1, 1}, {1, 2}, {1, 5}, {1, 6}}},
+		{4, 5, []Coord{{1, 1}, {1, 2}, {1, 3}, {1, 4}, {1, 5}, {1, 6}}},
+	}
+	for _, e := range tests {
+		if err := g.AddPortal(Coord{1, 5}, Coord{1, 2}, e.cost); err != nil {
+			t.Fatalf("g.AddPortal((1,5), (1,2), %v) = %v, expected: <nil>", e.cost, err)
+		}
+		if i, s := g.GetFastestPath(); i != e.exp || !coordSliceEq(s, e.path) {
+			t.Errorf("cost %v: g.GetFastestPath() = %v, %v; expected: %v, %v", e.cost, i, s, e.exp, e.path)
+		}
+		if i, s, _, _ := g.AStar(Zero); i != e.exp || len(s) != len(e.path) {
+			t.Errorf("cost %v: g.AStar(Zero) = %v, %v; expected: %v, %v", e.cost, i, s, e.exp, e.path)
+		}
+		if i, s, _ := g.CheapestPath(); i != e.exp || len(s) != len(e.path) {
+			t.Errorf("cost %v: g.CheapestPath() = %v, %v; expected: %v, %v", e.cost, i, s, e.exp, e.path)
+		}
+	}
+
+	g.AddPortal(Coord{1, 2}, Coord{1, 5}, 3)
+	if n := g.CountShortestPaths(); n.Int64() != 2 {
+		t.Errorf("g.CountShortestPaths() = %v, expected: 2", n)
+	}
+	paths := 0
+	g.ShortestPaths(0)(func(path []Coord) bool {
+		if len(path) != 4 && len(path) != 6 {
+			t.Errorf("g.ShortestPaths(0) yielded %v", path)
+		}
+		paths++
+		return true
+	})
+	if paths != 2 {
+		t.Errorf("g.ShortestPaths(0) yielded %v paths, expected: 2", paths)
+	}
+	g.SetCost(Coord{1, 3}, 5)
+	if i, s, _ := g.CheapestPath(); i != 5 || len(s) != 4 {
+		t.Errorf("g.CheapestPath() = %v, %v; expected: 5 through the portal", i, s)
+	}
+
+	g.AddPortal(Coord{1, 2}, Coord{1, 5}, 1)
+	expString := "\n.-------.-------.-------.-------.-------.-------.\n| ( s )   (pa )   (1,3)   (1,4)   (pa )   ( f ) |\n'-------'-------'-------'-------'-------'-------'\n\ndistance =3"
+	if res := g.StringFastestPath(); res != expString {
+		t.Errorf("g.StringFastestPath() = %v, expected: %v", res, expString)
+	}
+
+	g.SetObstacle(Coord{1, 5})
+	g.ClearObstacle(Coord{1, 5})
+	g.AddWall(Coord{1, 5}, Coord{1, 6})
+	g.SetObstacle(Coord{1, 2})
+	if i, _ := g.GetFastestPath(); i != 0 {
+		t.Errorf("g.GetFastestPath() = %v, expected no path past the obstacle", i)
+	}
+	g.ClearObstacle(Coord{1, 2})
+	g.RemoveWall(Coord{1, 5}, Coord{1, 6})
+	if err := g.RemovePortal(Coord{1, 5}, Coord{1, 2}); err != nil {
+		t.Errorf("g.RemovePortal((1,5), (1,2)) = %v, expected: <nil>", err)
+	}
+	if i, _ := g.GetFastestPath(); i != 5 {
+		t.Errorf("g.GetFastestPath() = %v, expected: 5 after RemovePortal", i)
+	}
+}
+
+func TestAddOneWayPortal(t *testing.T) {
+	g, _ := New(1, 6)
+	g.AddStart(1, 1)
+	g.AddFinish(1, 6)
+	g.AddOneWayPortal(Coord{1, 5}, Coord{1, 2}, 1)
+	if i, _ := g.GetFastestPath(); i != 5 {
+		t.Errorf("g.GetFastestPath() = %v, expected: 5", i)
+	}
+
+	g, _ = New(1, 6)
+	g.AddStart(1, 6)
+	g.AddFinish(1, 1)
+	g.AddOneWayPortal(Coord{1, 5}, Coord{1, 2}, 1)
+	if i, _ := g.GetFastestPath(); i != 3 {
+		t.Errorf("g.GetFastestPath() = %v, expected: 3", i)
+	}
+	expString := "\n.-------.-------.-------.-------.-------.-------.\n| ( f )   (pa )   (1,3)   (1,4)   (pa>)   ( s ) |\n'-------'-------'-------'-------'-------'-------'\n\ndistance =3"
+	if res := g.StringFastestPath(); res != expString {
+		t.Errorf("g.StringFastestPath() = %v, expected: %v", res, expString)
+	}
+
+	var tests = []struct {
+		from, to Coord
+		cost     int
+		exp      error
+	}{
+		{Coord{0, 1}, Coord{1, 2}, 1, ErrOutOfBounds},
+		{Coord{1, 1}, Coord{1, 7}, 1, ErrOutOfBounds},
+		{Coord{1, 3}, Coord{1, 3}, 1, ErrNotAdjacent},
+		{Coord{1, 3}, Coord{1, 4}, 0, ErrInvalidCost},
+		{Coord{1, 3}, Coord{1, 4}, 2, nil},
+	}
+	for _, e := range tests {
+		if err := g.AddOneWayPortal(e.from, e.to, e.cost); !errors.Is(err, e.exp) {
+			t.Errorf("g.AddOneWayPortal(%v, %v, %v) = %v, expected: %v", e.from, e.to, e.cost, err, e.exp)
+		}
+	}
+	g.SetObstacle(Coord{1, 3})
+	if err := g.AddPortal(Coord{1, 3}, Coord{1, 1}, 1); !errors.Is(err, ErrIsObstacle) {
+		t.Errorf("g.AddPortal((1,3), (1,1), 1) = %v, expected: %v", err, ErrIsObstacle)
+	}
+}
+
+func TestPortalMarks(t *testing.T) {
+	g, _ := New(2, 30)
+	for x := 1; x <= 30; x++ {
+		g.AddPortal(Coord{1, x}, Coord{2, x}, 1)
+	}
+	marks := g.portalMarks(map[int]string{g.index(Coord{1, 28}): "p"})
+	var tests = []struct {
+		c   Coord
+		exp string
+	}{
+		{Coord{1, 1}, "a"},
+		{Coord{2, 26}, "z"},
+		{Coord{1, 27}, "aa"},
+		{Coord{2, 27}, "aa"},
+		{Coord{1, 28}, "pab"},
+		{Coord{2, 30}, "ad"},
+	}
+	for _, e := range tests {
+		if res := marks[g.index(e.c)]; res != e.exp {
+			t.Errorf("g.portalMarks() at %v = %q, expected: %q", e.c, res, e.exp)
+		}
+	}
+	if res := g.String(); !strings.Contains(res, "(ad )") {
+		t.Errorf("g.String() = %v, expected the label (ad )", res)
+	}
+
+	g, _ = New(1, 3)
+	g.AddOneWayPortal(Coord{1, 1}, Coord{1, 3}, 1)
+	if res := g.portalMarks(map[int]string{0: "pk"})[0]; res != "a>" {
+		t.Errorf("g.portalMarks() = %q, expected: \"a>\" when the path mark doesn't fit", res)
+	}
+	// A hub vertex has the same label as every vertex it's linked to.
+	g, _ = New(1, 8)
+	g.AddPortal(Coord{1, 2}, Coord{1, 4}, 1)
+	g.AddPortal(Coord{1, 2}, Coord{1, 6}, 1)
+	g.AddPortal(Coord{1, 7}, Coord{1, 8}, 1)
+	exp := ".-------.-------.-------.-------.-------.-------.-------.-------.\n| (1,1)   ( a )   (1,3)   ( a )   (1,5)   ( a )   ( b )   ( b ) |\n'-------'-------'-------'-------'-------'-------'-------'-------'\n"
+	if res := g.String(); res != exp {
+		t.Errorf("g.String() = %v, expected: %v", res, exp)
+	}
+	marks = g.portalMarks(map[int]string{1: "p", 5: "p"})
+	if marks[1] != "pa" || marks[3] != "a" || marks[5] != "pa" {
+		t.Errorf("g.portalMarks() = %q, expected the same label at both ends of the route", marks)
+	}
+
+	for k, exp := range map[int]string{0: "a", 25: "z", 26: "aa", 701: "zz", 702: "a"} {
+		if res := portalLabel(k); res != exp {
+			t.Errorf("portalLabel(%v) = %q, expected: %q", k, res, exp)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package maze
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Legend maps the characters of a character grid to the vertices of a graph,
+// see FromRows and Rows.
+type Legend struct {
+	Open     rune
+	Obstacle rune
+	Start    rune
+	Finish   rune
+
+	// Costs contains the cost of every character of weighted terrain, like mud or
+	// water, which is an open vertex with the cost, see SetCost.
+	Costs map[rune]int
+}
+
+// DefaultLegend is the legend of the most common character grids, where '.' is an
+// open vertex, '#' an obstacle, 'S' the startVertex and 'F' the finishVertex.
+var DefaultLegend = Legend{Open: '.', Obstacle: '#', Start: 'S', Finish: 'F'}
+
+// The function FromRows creates a graph from a character grid, where every row is
+// a row of the graph and every character one vertex, e.g. "S..#" is a row with the
+// startVertex, two open vertices and an obstacle with DefaultLegend. The options are
+// the same as for New, and a legend with several start or finish characters in the
+// grid gives several start- or finishVertices, see AppendStart.
+//
+// FromRows returns an error wrapping ErrInvalidSize if there are no rows or if the rows
+// don't have the same number of characters, an error wrapping ErrInvalidFormat with the
+// row and column of a character that isn't in the legend and the errors of New and SetCost.
+func FromRows(rows []string, legend Legend, options ...Option) (*Graph, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no rows", ErrInvalidSize)
+	}
+	width := utf8.RuneCountInString(rows[0])
+	for y, row := range rows {
+		if n := utf8.RuneCountInString(row); n != width {
+			return nil, fmt.Errorf("%w: row %d has %d characters, expected %d", ErrInvalidSize, y+1, n, width)
+		}
+	}
+	g, err := New(len(rows), width, options...)
+	if err != nil {
+		return nil, err
+	}
+	for y, row := range rows {
+		x := 0
+		for _, r := range row {
+			c := Coord{y + 1, x + 1}
+			x++
+			i := g.index(c)
+			switch r {
+			case legend.Open:
+			case legend.Obstacle:
+				g.cells[i] |= obstacleFlag
+			case legend.Start:
+				g.cells[i] |= startFlag
+				g.starts = append(g.starts, i)
+			case legend.Finish:
+				g.cells[i] |= finishFlag
+				g.finishes = append(g.finishes, i)
+			default:
+				cost, found := legend.Costs[r]
+				if !found {
+					return nil, fmt.Errorf("%w: row %d, column %d: %q isn't in the legend", ErrInvalidFormat, c.Row, c.Col, r)
+				}
+				if err := g.SetCost(c, cost); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	g.refreshAll()
+	return g, nil
+}
+
+// The method Rows returns the graph as a character grid with the legend, the opposite of
+// FromRows. The start- and finishVertices are displayed as Start and Finish, and an open
+// vertex with a cost other than 1 as the character in Costs with that cost, or as Open
+// if there's none. The walls, portals, keys and doors aren't part of the grid.
+func (g *Graph) Rows(legend Legend) []string {
+	// terrain contains the character of every cost, the smallest if there are several.
+	terrain := make(map[int]rune, len(legend.Costs))
+	for r, cost := range legend.Costs {
+		if t, found := terrain[cost]; !found || r < t {
+			terrain[cost] = r
+		}
+	}
+	rows := make([]string, g.height)
+	for y := range rows {
+		row := make([]rune, g.width)
+		for x := range row {
+			i := y*g.width + x
+			row[x] = legend.Open
+			switch {
+			case g.cells[i]&obstacleFlag != 0:
+				row[x] = legend.Obstacle
+			case g.cells[i]&startFlag != 0:
+				row[x] = legend.Start
+			case g.cells[i]&finishFlag != 0:
+				row[x] = legend.Finish
+			case g.cost(i) != 1:
+				if r, found := terrain[g.cost(i)]; found {
+					row[x] = r
+				}
+			}
+		}
+		rows[y] = string(row)
+	}
+	return rows
+}
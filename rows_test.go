@@ -0,0 +1,72 @@
+package maze
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFromRows(t *testing.T) {
+	rows := []string{
+		"S..#....",
+		".#.#.##.",
+		".#...#..",
+		".####.#.",
+		"......#F",
+	}
+	g, err := FromRows(rows, DefaultLegend)
+	if err != nil {
+		t.Fatalf("FromRows() error = %v, expected: <nil>", err)
+	}
+	h, _ := New(5, 8)
+	h.AddStart(1, 1)
+	h.AddFinish(5, 8)
+	for _, c := range []Coord{{1, 4}, {2, 2}, {2, 4}, {2, 6}, {2, 7}, {3, 2}, {3, 6}, {4, 2}, {4, 3}, {4, 4}, {4, 5}, {4, 7}, {5, 7}} {
+		h.AddObstacle(c.Row, c.Col)
+	}
+	if g.String() != h.String() {
+		t.Errorf("FromRows() = \n%v, expected: \n%v", g, h)
+	}
+	if distance, _ := g.GetFastestPath(); distance != 15 {
+		t.Errorf("g.GetFastestPath() = %v, expected: 15", distance)
+	}
+	if res := g.Rows(DefaultLegend); !reflect.DeepEqual(res, rows) {
+		t.Errorf("g.Rows(DefaultLegend) = %q, expected: %q", res, rows)
+	}
+
+	legend := Legend{Open: ' ', Obstacle: '█', Start: 'A', Finish: 'B', Costs: map[rune]int{'~': 5, '≈': 5, ',': 2}}
+	rows = []string{
+		"A ~█",
+		",,≈B",
+		"AB  ",
+	}
+	g, err = FromRows(rows, legend, WithDiagonals(CornersAllowed))
+	if err != nil {
+		t.Fatalf("FromRows() error = %v, expected: <nil>", err)
+	}
+	if g.Cost(Coord{1, 3}) != 5 || g.Cost(Coord{2, 1}) != 2 || len(g.Starts()) != 2 || len(g.Finishes()) != 2 {
+		t.Errorf("FromRows() costs = %v, %v; starts = %v, finishes = %v", g.Cost(Coord{1, 3}), g.Cost(Coord{2, 1}), g.Starts(), g.Finishes())
+	}
+	exp := []string{"A ~█", ",,~B", "AB  "}
+	if res := g.Rows(legend); !reflect.DeepEqual(res, exp) {
+		t.Errorf("g.Rows(legend) = %q, expected: %q", res, exp)
+	}
+
+	var tests = []struct {
+		rows []string
+		exp  error
+	}{
+		{nil, ErrInvalidSize},
+		{[]string{"...", ".."}, ErrInvalidSize},
+		{[]string{"..", ".x"}, ErrInvalidFormat},
+	}
+	for _, e := range tests {
+		if _, err := FromRows(e.rows, DefaultLegend); !errors.Is(err, e.exp) {
+			t.Errorf("FromRows(%q) = %v, expected: %v", e.rows, err, e.exp)
+		}
+	}
+	legend.Costs['~'] = 0
+	if _, err := FromRows([]string{"~"}, legend); !errors.Is(err, ErrInvalidCost) {
+		t.Errorf("FromRows() with cost 0 = %v, expected: %v", err, ErrInvalidCost)
+	}
+}
@@ -0,0 +1,221 @@
+package maze
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Schedule decides at which time steps a vertex is blocked, like a gate that opens
+// and closes or a patrol passing by, see SetSchedule.
+type Schedule interface {
+	// Blocked reports whether the vertex is blocked at the time step t.
+	Blocked(t int) bool
+}
+
+// Periodic is a Schedule that repeats every Period steps, where the vertex is blocked
+// for Duration steps starting at Offset, i.e. at the steps Offset, Offset+1, ...,
+// Offset+Duration-1, Offset+Period, ... A Periodic with Period <= 0 is never blocked.
+type Periodic struct {
+	Period   int
+	Duration int
+	Offset   int
+}
+
+// The method Blocked reports whether the vertex is blocked at the time step t.
+func (p Periodic) Blocked(t int) bool {
+	if p.Period <= 0 {
+		return false
+	}
+	r := (t - p.Offset) % p.Period
+	if r < 0 {
+		r += p.Period
+	}
+	return r < p.Duration
+}
+
+// Interval is the time steps From, From+1, ..., To.
+type Interval struct {
+	From int
+	To   int
+}
+
+// Intervals is a Schedule where the vertex is blocked during every interval,
+// e.g. Intervals{{3, 3}, {7, 9}} is blocked at the steps 3, 7, 8 and 9.
+type Intervals []Interval
+
+// The method Blocked reports whether the vertex is blocked at the time step t.
+func (s Intervals) Blocked(t int) bool {
+	for _, interval := range s {
+		if interval.From <= t && t <= interval.To {
+			return true
+		}
+	}
+	return false
+}
+
+// TimedStep is a vertex on a timed path and the time step the path is at the vertex.
+type TimedStep struct {
+	Coord
+	Step int
+}
+
+// The method String returns the step on the format "(y,x)@t".
+func (s TimedStep) String() string {
+	return s.Coord.String() + "@" + strconv.Itoa(s.Step)
+}
+
+// The method SetSchedule makes the specified vertex an obstacle at the time steps
+// the schedule is blocked, which only TimedPath knows about. A nil schedule removes
+// the schedule of the vertex.
+//
+// SetSchedule returns an error if the vertex is outside of the graph.
+func (g *Graph) SetSchedule(c Coord, s Schedule) error {
+	if err := g.checkBounds(c); err != nil {
+		return err
+	}
+	i := g.index(c)
+	if s == nil {
+		delete(g.schedules, i)
+		return nil
+	}
+	if g.schedules == nil {
+		g.schedules = make(map[int]Schedule)
+	}
+	g.schedules[i] = s
+	return nil
+}
+
+// The method TimedPath returns the path from the startVertex to the finishVertex that
+// arrives first when the vertices with a schedule are blocked at some time steps, see
+// SetSchedule. The path starts at step 0, every step to an adjencent vertex takes one
+// time step and a portal takes its cost. The path can also wait at a vertex, which
+// shows up as the same vertex at consecutive steps.
+//
+// The search is a BFS over the pairs of a vertex and a time step, so it only looks
+// horizon steps ahead. With several start- or finishVertices the path is the one from
+// any startVertex that arrives first at any finishVertex.
+//
+// TimedPath returns an error wrapping ErrNoPath if the start- or finishVertex isn't
+// set or if no finishVertex can be reached within horizon steps.
+func (g *Graph) TimedPath(horizon int) ([]TimedStep, error) {
+	if err := g.checkEndpoints(); err != nil {
+		return nil, err
+	}
+	path := g.timedSearch(g.starts, horizon, g.blockedAt, nil, func(i int, t int) bool {
+		return g.cells[i]&finishFlag != 0
+	})
+	if path == nil {
+		return nil, fmt.Errorf("%w: no finishVertex can be reached within %d steps", ErrNoPath, horizon)
+	}
+	return path, nil
+}
+
+// blockedAt reports whether the vertex i is blocked by its schedule at the step t.
+func (g *Graph) blockedAt(i int, t int) bool {
+	s, found := g.schedules[i]
+	return found && s.Blocked(t)
+}
+
+// timedSearch is the space-time BFS of TimedPath, which visits the pairs of a vertex
+// and a time step from the sources at step 0 until a vertex i where done(i, t) is
+// reached. The vertex i can't be visited at the step t if blocked(i, t), and the edge
+// from a to x can't be taken at the step t if crosses(a, x, t). It returns the timed
+// path, or nil if no such vertex is reached within horizon steps.
+func (g *Graph) timedSearch(sources []int, horizon int, blocked func(i int, t int) bool, crosses func(a int, x int, t int) bool, done func(i int, t int) bool) []TimedStep {
+	// predecessor contains the previous state of every reached state, which has the
+	// index t*n + i where i is the index of the vertex, and seen[i] is t+1 if the
+	// vertex i has been reached at step t.
+	n := len(g.cells)
+	predecessor := make(map[int]int)
+	seen := make([]int, n)
+	var layer []int
+	for _, i := range sources {
+		if !blocked(i, 0) {
+			predecessor[i] = i
+			seen[i] = 1
+			layer = append(layer, i)
+		}
+	}
+	pending := make(map[int][][2]int)
+
+	for t := 0; t <= horizon && (len(layer) > 0 || len(pending) > 0); t++ {
+		for _, i := range layer {
+			if done(i, t) {
+				return g.timedPath(predecessor, t*n+i)
+			}
+		}
+		var next []int
+		reach := func(x int, from int) {
+			if seen[x] != t+2 && !blocked(x, t+1) {
+				seen[x] = t + 2
+				predecessor[(t+1)*n+x] = from
+				next = append(next, x)
+			}
+		}
+		// Waiting is tried before moving, so the path waits where it is instead
+		// of going back and forth.
+		for _, a := range layer {
+			reach(a, t*n+a)
+		}
+		for _, a := range layer {
+			for k, m := 0, g.edges(a); k < m; k++ {
+				x, length, ok := g.successor(a, k)
+				if !ok || crosses != nil && crosses(a, x, t) {
+					continue
+				}
+				if length > 1 {
+					pending[t+length] = append(pending[t+length], [2]int{t*n + a, x})
+					continue
+				}
+				reach(x, t*n+a)
+			}
+		}
+		for _, e := range pending[t+1] {
+			reach(e[1], e[0])
+		}
+		delete(pending, t+1)
+		layer = next
+	}
+	return nil
+}
+
+// timedPath follows the predecessors of TimedPath from the state s back to a
+// startVertex and returns the timed path in the opposite order.
+func (g *Graph) timedPath(predecessor map[int]int, s int) []TimedStep {
+	n := len(g.cells)
+	var path []TimedStep
+	for {
+		path = append(path, TimedStep{g.coord(s % n), s / n})
+		if predecessor[s] == s {
+			break
+		}
+		s = predecessor[s]
+	}
+	for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+		path[l], path[r] = path[r], path[l]
+	}
+	return path
+}
+
+// The method StringTimedPath returns a ASCII representation of the path returned by
+// TimedPath and its arrival step, like StringFastestPath.
+//
+// The vertices on the path are displayed with the step the path arrives at them,
+// e.g. ( 3 ), so the waiting can be seen as a jump in the steps. From step 1000 only
+// the last two digits are displayed after a "~", e.g. (~34), so the grid stays aligned.
+func (g *Graph) StringTimedPath(horizon int) string {
+	path, err := g.TimedPath(horizon)
+	marks := make(map[int]string, len(path))
+	coords := make([]Coord, len(path))
+	for k, s := range path {
+		if _, found := marks[g.index(s.Coord)]; !found {
+			marks[g.index(s.Coord)] = strconv.Itoa(s.Step)
+		}
+		coords[k] = s.Coord
+	}
+	arrival := 0
+	if len(path) > 0 {
+		arrival = path[len(path)-1].Step
+	}
+	return g.stringMarks(marks, coords, "arrival =", arrival, err)
+}
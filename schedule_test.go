@@ -0,0 +1,88 @@
+package maze
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSchedules(t *testing.T) {
+	var tests = []struct {
+		s   Schedule
+		t   int
+		exp bool
+	}{
+		{Periodic{Period: 4, Duration: 2}, 0, true},
+		{Periodic{Period: 4, Duration: 2}, 2, false},
+		{Periodic{Period: 4, Duration: 2}, 5, true},
+		{Periodic{Period: 4, Duration: 2, Offset: 1}, 0, false},
+		{Periodic{Period: 4, Duration: 2, Offset: 1}, 2, true},
+		{Periodic{Period: 0, Duration: 2}, 0, false},
+		{Intervals{{3, 3}, {7, 9}}, 3, true},
+		{Intervals{{3, 3}, {7, 9}}, 4, false},
+		{Intervals{{3, 3}, {7, 9}}, 9, true},
+	}
+	for _, e := range tests {
+		if res := e.s.Blocked(e.t); res != e.exp {
+			t.Errorf("%v.Blocked(%v) = %v, expected: %v", e.s, e.t, res, e.exp)
+		}
+	}
+}
+
+func TestTimedPath(t *testing.T) {
+	g, _ := New(1, 4)
+	g.AddStart(1, 1)
+	g.AddFinish(1, 4)
+	if err := g.SetSchedule(Coord{1, 3}, Intervals{{1, 3}}); err != nil {
+		t.Fatalf("g.SetSchedule((1,3), ...) = %v, expected: <nil>", err)
+	}
+	exp := []TimedStep{{Coord{1, 1}, 0}, {Coord{1, 2}, 1}, {Coord{1, 2}, 2}, {Coord{1, 2}, 3}, {Coord{1, 3}, 4}, {Coord{1, 4}, 5}}
+	path, err := g.TimedPath(10)
+	if err != nil || len(path) != len(exp) {
+		t.Fatalf("g.TimedPath(10) = %v, %v; expected: %v, <nil>", path, err, exp)
+	}
+	for k := range exp {
+		if path[k] != exp[k] {
+			t.Errorf("g.TimedPath(10) = %v, expected: %v", path, exp)
+			break
+		}
+	}
+	if _, err := g.TimedPath(4); !errors.Is(err, ErrNoPath) {
+		t.Errorf("g.TimedPath(4) error = %v, expected: %v", err, ErrNoPath)
+	}
+	expString := "\n.-------.-------.-------.-------.\n| ( s )   ( 1 )   ( 4 )   ( f ) |\n'-------'-------'-------'-------'\n\narrival =5"
+	if res := g.StringTimedPath(10); res != expString {
+		t.Errorf("g.StringTimedPath(10) = %v, expected: %v", res, expString)
+	}
+
+	g.SetSchedule(Coord{1, 3}, Periodic{Period: 2, Duration: 1})
+	if path, err := g.TimedPath(10); err != nil || path[len(path)-1].Step != 4 {
+		t.Errorf("g.TimedPath(10) = %v, %v; expected to arrive at step 4", path, err)
+	}
+	g.SetSchedule(Coord{1, 3}, nil)
+	if path, err := g.TimedPath(10); err != nil || path[len(path)-1].Step != 3 {
+		t.Errorf("g.TimedPath(10) = %v, %v; expected to arrive at step 3", path, err)
+	}
+	g.SetSchedule(Coord{1, 1}, Intervals{{0, 0}})
+	if _, err := g.TimedPath(10); !errors.Is(err, ErrNoPath) {
+		t.Errorf("g.TimedPath(10) with a blocked startVertex error = %v, expected: %v", err, ErrNoPath)
+	}
+	if err := g.SetSchedule(Coord{2, 1}, nil); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("g.SetSchedule((2,1), nil) = %v, expected: %v", err, ErrOutOfBounds)
+	}
+
+	// The steps from 1000 are shortened so every vertex keeps its width.
+	long, _ := New(1, 1100)
+	long.AddStart(1, 1)
+	long.AddFinish(1, 1100)
+	res := long.StringTimedPath(2000)
+	lines := strings.Split(res, "\n")
+	if len(lines[2]) != len(lines[1]) {
+		t.Errorf("long.StringTimedPath(2000) isn't aligned: %q and %q", lines[1][:50], lines[2][:50])
+	}
+	for _, label := range []string{"(999)", "(~00)", "(~34)"} {
+		if !strings.Contains(lines[2], label) {
+			t.Errorf("long.StringTimedPath(2000) doesn't display %v", label)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package maze
+
+import (
+	"math"
+	"math/big"
+)
+
+// The method CountShortestPaths returns the number of distinct shortest paths from the
+// startVertices to the nearest finishVertices, i.e. the number of paths with the
+// distance returned by GetFastestPath. It returns 0 if there's no path.
+//
+// The number can grow exponentially with the size of the graph, so it's counted
+// with big integers when it doesn't fit in 64 bits.
+func (g *Graph) CountShortestPaths() *big.Int {
+	distance, order, shortest := g.shortestPathLayers()
+	if shortest < 0 {
+		return new(big.Int)
+	}
+
+	// count[x] is the number of shortest paths from the startVertices to x,
+	// the vertices are visited in order of distance so every predecessor is counted before x.
+	count := make([]uint64, len(g.cells))
+	for _, i := range order {
+		if distance[i] == 0 {
+			count[i] = 1
+		}
+	}
+	overflow := false
+	for _, a := range order {
+		a := int(a)
+		for k, n := 0, g.edges(a); k < n; k++ {
+			if x, length, ok := g.successor(a, k); ok && int(distance[x]) == int(distance[a])+length {
+				if count[x] > math.MaxUint64-count[a] {
+					overflow = true
+				}
+				count[x] += count[a]
+			}
+		}
+	}
+	if !overflow {
+		total := new(big.Int)
+		for _, i := range g.finishes {
+			if int(distance[i]) == shortest {
+				total.Add(total, new(big.Int).SetUint64(count[i]))
+			}
+		}
+		return total
+	}
+
+	bigCount := make([]*big.Int, len(g.cells))
+	for _, i := range order {
+		bigCount[i] = new(big.Int)
+		if distance[i] == 0 {
+			bigCount[i].SetInt64(1)
+		}
+	}
+	for _, a := range order {
+		a := int(a)
+		for k, n := 0, g.edges(a); k < n; k++ {
+			if x, length, ok := g.successor(a, k); ok && int(distance[x]) == int(distance[a])+length {
+				bigCount[x].Add(bigCount[x], bigCount[a])
+			}
+		}
+	}
+	total := new(big.Int)
+	for _, i := range g.finishes {
+		if int(distance[i]) == shortest {
+			total.Add(total, bigCount[i])
+		}
+	}
+	return total
+}
+
+// The method ShortestPaths returns an iterator over the distinct shortest paths from
+// the startVertices to the nearest finishVertices, see CountShortestPaths. At most
+// limit paths are yielded, or all of them if limit <= 0.
+//
+// The iterator can be used in a range loop,
+//
+//	for path := range g.ShortestPaths(2) {
+//		fmt.Println(path)
+//	}
+//
+// or be called with a yield function that returns false to stop the iteration.
+// Every yielded path is a new slice.
+func (g *Graph) ShortestPaths(limit int) func(yield func([]Coord) bool) {
+	return func(yield func([]Coord) bool) {
+		distance, order, shortest := g.shortestPathLayers()
+		if shortest < 0 {
+			return
+		}
+
+		// onPath marks the vertices on any shortest path, found by going
+		// backwards through the visiting order from the nearest finishVertices.
+		onPath := make([]bool, len(g.cells))
+		for _, i := range g.finishes {
+			onPath[i] = int(distance[i]) == shortest
+		}
+		for k := len(order) - 1; k >= 0; k-- {
+			a := int(order[k])
+			for e, n := 0, g.edges(a); e < n; e++ {
+				if x, length, ok := g.successor(a, e); ok && onPath[x] && int(distance[x]) == int(distance[a])+length {
+					onPath[a] = true
+					break
+				}
+			}
+		}
+
+		// Depth-first search from every startVertex along the marked vertices,
+		// where next[k] is the next edge to try from path[k], see successor.
+		yielded := 0
+		path := make([]int, 0, shortest+1)
+		next := make([]int, 0, shortest+1)
+		for _, s := range g.starts {
+			if !onPath[s] {
+				continue
+			}
+			path, next = append(path[:0], s), append(next[:0], 0)
+			for len(path) > 0 {
+				k := len(path) - 1
+				a := path[k]
+				if int(distance[a]) == shortest {
+					if !yield(g.coords(path)) {
+						return
+					}
+					if yielded++; limit > 0 && yielded >= limit {
+						return
+					}
+					path, next = path[:k], next[:k]
+					continue
+				}
+				e, n := next[k], g.edges(a)
+				for ; e < n; e++ {
+					if x, length, ok := g.successor(a, e); ok && onPath[x] && int(distance[x]) == int(distance[a])+length {
+						break
+					}
+				}
+				if e == n {
+					path, next = path[:k], next[:k]
+					continue
+				}
+				next[k] = e + 1
+				x, _, _ := g.successor(a, e)
+				path, next = append(path, x), append(next, 0)
+			}
+		}
+	}
+}
+
+// shortestPathLayers visits the vertices with increasing distance from the startVertices,
+// like fastestPathBFS, but finishes the layer of the nearest finishVertex. It returns the
+// distance of every vertex, where -1 means that it hasn't been visited, the visited
+// vertices in order of increasing distance and the distance of the nearest finishVertex,
+// or -1 if there's no start- or finishVertex or no path between them.
+func (g *Graph) shortestPathLayers() ([]int32, []int32, int) {
+	if g.checkEndpoints() != nil {
+		return nil, nil, -1
+	}
+	distance, _, order, finish := g.search(g.starts, -1, true)
+	if finish < 0 {
+		return distance, order, -1
+	}
+	return distance, order, int(distance[finish])
+}
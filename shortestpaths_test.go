@@ -0,0 +1,97 @@
+package maze
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestCountShortestPaths(t *testing.T) {
+	g_3 := NewGraph(3, 3)
+	g_3.AddStart(1, 1)
+	g_3.AddFinish(3, 3)
+
+	g_unique := NewGraph(5, 3)
+	g_unique.AddStart(1, 1)
+	g_unique.AddFinish(1, 3)
+	g_unique.AddObstacle(1, 2)
+	g_unique.AddObstacle(2, 2)
+	g_unique.AddObstacle(4, 2)
+
+	g_40 := NewGraph(40, 40)
+	g_40.AddStart(1, 1)
+	g_40.AddFinish(40, 40)
+
+	g_multi := NewGraph(3, 3)
+	g_multi.AddStart(2, 1)
+	g_multi.AppendStart(Coord{2, 3})
+	g_multi.AddFinish(1, 2)
+	g_multi.AppendFinish(Coord{3, 2})
+
+	g_none := NewGraph(2, 2)
+	g_none.AddStart(1, 1)
+	g_none.AddFinish(2, 2)
+	g_none.AddObstacle(1, 2)
+	g_none.AddObstacle(2, 1)
+
+	var tests = []struct {
+		name string
+		g    Graph
+		exp  *big.Int
+	}{
+		{"g_3", g_3, big.NewInt(6)},
+		{"g_unique", g_unique, big.NewInt(1)},
+		{"g_40", g_40, new(big.Int).Binomial(78, 39)},
+		{"g_multi", g_multi, big.NewInt(8)},
+		{"g_none", g_none, big.NewInt(0)},
+	}
+	for _, e := range tests {
+		if res := e.g.CountShortestPaths(); res.Cmp(e.exp) != 0 {
+			t.Errorf("%v.CountShortestPaths() = %v, expected: %v", e.name, res, e.exp)
+		}
+	}
+}
+
+func TestShortestPaths(t *testing.T) {
+	g := NewGraph(3, 3)
+	g.AddStart(1, 1)
+	g.AddFinish(3, 3)
+
+	seen := make(map[string]bool)
+	g.ShortestPaths(0)(func(path []Coord) bool {
+		if len(path) != 5 || path[0] != (Coord{1, 1}) || path[4] != (Coord{3, 3}) {
+			t.Errorf("g.ShortestPaths(0) yielded %v, expected a path from (1,1) to (3,3)", path)
+		}
+		for k := 1; k < len(path); k++ {
+			if abs(path[k].Row-path[k-1].Row)+abs(path[k].Col-path[k-1].Col) != 1 {
+				t.Errorf("g.ShortestPaths(0) yielded %v, expected adjencent vertices", path)
+			}
+		}
+		key := ""
+		for _, c := range path {
+			key += c.String()
+		}
+		seen[key] = true
+		return true
+	})
+	if len(seen) != 6 {
+		t.Errorf("g.ShortestPaths(0) yielded %v distinct paths, expected: 6", len(seen))
+	}
+
+	n := 0
+	g.ShortestPaths(4)(func(path []Coord) bool {
+		n++
+		return true
+	})
+	if n != 4 {
+		t.Errorf("g.ShortestPaths(4) yielded %v paths, expected: 4", n)
+	}
+
+	n = 0
+	g.ShortestPaths(0)(func(path []Coord) bool {
+		n++
+		return n < 2
+	})
+	if n != 2 {
+		t.Errorf("g.ShortestPaths(0) yielded %v paths after stopping, expected: 2", n)
+	}
+}
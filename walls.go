@@ -0,0 +1,114 @@
+package maze
+
+import "fmt"
+
+// The method AddWall places a wall between the adjencent vertices a and b,
+// i.e. removes the edge between them without making any of them an obstacle.
+//
+// A wall stays in place until it's removed with RemoveWall, also when an
+// obstacle next to it is removed. AddWall returns an error if a or b is
+// outside of the graph or if they aren't adjencent.
+func (g *Graph) AddWall(a Coord, b Coord) error {
+	i, j, d, err := g.edge(a, b)
+	if err != nil {
+		return err
+	}
+	g.cells[i] |= wall(d)
+	g.cells[j] |= wall(opposite(d))
+	g.refresh(i, d)
+	return nil
+}
+
+// The method RemoveWall removes the wall between the adjencent vertices a and b,
+// i.e. adds an edge between them if none of them is an obstacle.
+//
+// RemoveWall returns an error if a or b is outside of the graph or if they
+// aren't adjencent. Removing a wall that doesn't exist does nothing.
+func (g *Graph) RemoveWall(a Coord, b Coord) error {
+	i, j, d, err := g.edge(a, b)
+	if err != nil {
+		return err
+	}
+	g.cells[i] &^= wall(d)
+	g.cells[j] &^= wall(opposite(d))
+	g.refresh(i, d)
+	return nil
+}
+
+// The method HasWall reports whether there's a wall placed with AddWall between
+// the vertices a and b. Edges removed by obstacles aren't walls, and vertices
+// that aren't adjencent never have a wall between them.
+func (g *Graph) HasWall(a Coord, b Coord) bool {
+	i, _, d, err := g.edge(a, b)
+	if err != nil {
+		return false
+	}
+	return g.cells[i]&wall(d) != 0
+}
+
+// edge returns the indices of the vertices a and b and the direction from a to b.
+// It returns an error if a or b is outside of the graph or if they aren't adjencent.
+func (g *Graph) edge(a Coord, b Coord) (int, int, int, error) {
+	if err := g.checkBounds(a); err != nil {
+		return 0, 0, 0, err
+	}
+	if err := g.checkBounds(b); err != nil {
+		return 0, 0, 0, err
+	}
+	i, j := g.index(a), g.index(b)
+	for d := range directions {
+		if k, ok := g.adjacent(i, d); ok && k == j && g.dirs&passage(d) != 0 {
+			return i, j, d, nil
+		}
+	}
+	return 0, 0, 0, fmt.Errorf("%w: %v and %v", ErrNotAdjacent, a, b)
+}
+
+// The method SetOneWay makes the edge between the adjencent vertices from and to a one-way
+// passage, like a conveyor belt or a one-way door, that can only be taken from from to to.
+// Every path finder respects the direction, and the passage is displayed as an arrow
+// ">", "<", "v" or "^" instead of a gap in the wall, except on hex grids.
+//
+// Setting the opposite direction turns the passage around, and a wall or an obstacle
+// still closes it in both directions. SetOneWay returns an error if from or to is
+// outside of the graph or if they aren't adjencent, and an error wrapping ErrUnsupported
+// for the diagonal edges of a graph created WithDiagonals, which have no wall to
+// display the arrow in.
+func (g *Graph) SetOneWay(from Coord, to Coord) error {
+	i, j, d, err := g.edge(from, to)
+	if err != nil {
+		return err
+	}
+	if d >= 4 && !g.hex {
+		return fmt.Errorf("%w: the diagonal edge between %v and %v can't be one-way", ErrUnsupported, from, to)
+	}
+	g.cells[i] &^= oneWay(d)
+	g.cells[j] |= oneWay(opposite(d))
+	g.refresh(i, d)
+	return nil
+}
+
+// The method ClearOneWay makes the one-way passage between the adjencent vertices a and b
+// a two-way passage again. Clearing a one-way passage that doesn't exist does nothing.
+//
+// ClearOneWay returns an error if a or b is outside of the graph or if they aren't adjencent.
+func (g *Graph) ClearOneWay(a Coord, b Coord) error {
+	i, j, d, err := g.edge(a, b)
+	if err != nil {
+		return err
+	}
+	g.cells[i] &^= oneWay(d)
+	g.cells[j] &^= oneWay(opposite(d))
+	g.refresh(i, d)
+	return nil
+}
+
+// The method IsOneWay reports whether the edge between the vertices from and to is a
+// one-way passage from from to to, set with SetOneWay.
+func (g *Graph) IsOneWay(from Coord, to Coord) bool {
+	_, j, d, err := g.edge(from, to)
+	if err != nil {
+		return false
+	}
+	return g.cells[j]&oneWay(opposite(d)) != 0
+}
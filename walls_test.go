@@ -0,0 +1,141 @@
+package maze
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddWall(t *testing.T) {
+	g := NewGraph(2, 3)
+	for _, c := range []Coord{{1, 1}, {1, 3}} {
+		if err := g.AddWall(c, Coord{1, 2}); err != nil {
+			t.Errorf("g.AddWall(%v, (1,2)) = %v, expected: <nil>", c, err)
+		}
+	}
+	exp := ".-------.-------.-------.\n| (1,1) | (1,2) | (1,3) |\n:       +       +       +\n| (2,1)   (2,2)   (2,3) |\n'-------'-------'-------'\n"
+	if res := g.String(); res != exp {
+		t.Errorf("g.String() = %v, expected: %v", res, exp)
+	}
+	if !g.HasWall(Coord{1, 2}, Coord{1, 1}) || g.HasWall(Coord{1, 2}, Coord{2, 2}) {
+		t.Errorf("Error: AddWall not working properly, HasWall.")
+	}
+
+	var tests = []struct {
+		a, b Coord
+		exp  error
+	}{
+		{Coord{1, 1}, Coord{2, 2}, ErrNotAdjacent},
+		{Coord{1, 1}, Coord{1, 1}, ErrNotAdjacent},
+		{Coord{1, 3}, Coord{1, 4}, ErrOutOfBounds},
+		{Coord{0, 1}, Coord{1, 1}, ErrOutOfBounds},
+	}
+	for _, e := range tests {
+		if err := g.AddWall(e.a, e.b); !errors.Is(err, e.exp) {
+			t.Errorf("g.AddWall(%v, %v) = %v, expected: %v", e.a, e.b, err, e.exp)
+		}
+		if err := g.RemoveWall(e.a, e.b); !errors.Is(err, e.exp) {
+			t.Errorf("g.RemoveWall(%v, %v) = %v, expected: %v", e.a, e.b, err, e.exp)
+		}
+		if g.HasWall(e.a, e.b) {
+			t.Errorf("g.HasWall(%v, %v) = true, expected: false", e.a, e.b)
+		}
+	}
+}
+
+func TestRemoveWall(t *testing.T) {
+	g := NewGraph(3, 3)
+	g.AddWall(Coord{2, 2}, Coord{1, 2})
+	g.AddWall(Coord{2, 2}, Coord{2, 3})
+	g.AddObstacle(2, 2)
+	g.RemoveObstacle(2, 2)
+	if passages(&g, Coord{2, 2}) != 2 || !g.HasWall(Coord{1, 2}, Coord{2, 2}) {
+		t.Errorf("Error: RemoveObstacle reopened a wall.")
+	}
+
+	g.AddObstacle(2, 3)
+	g.RemoveWall(Coord{2, 3}, Coord{2, 2})
+	if g.HasWall(Coord{2, 2}, Coord{2, 3}) || passages(&g, Coord{2, 3}) != 0 {
+		t.Errorf("Error: RemoveWall not working properly next to an obstacle.")
+	}
+	g.RemoveWall(Coord{1, 2}, Coord{2, 2})
+	if g.HasWall(Coord{2, 2}, Coord{1, 2}) || passages(&g, Coord{2, 2}) != 3 {
+		t.Errorf("Error: RemoveWall not working properly, vertex passages.")
+	}
+}
+
+func TestSetOneWay(t *testing.T) {
+	g, _ := New(2, 3)
+	g.AddStart(1, 1)
+	g.AddFinish(1, 3)
+	if err := g.SetOneWay(Coord{1, 3}, Coord{1, 2}); err != nil {
+		t.Fatalf("g.SetOneWay((1,3), (1,2)) = %v, expected: <nil>", err)
+	}
+	if !g.IsOneWay(Coord{1, 3}, Coord{1, 2}) || g.IsOneWay(Coord{1, 2}, Coord{1, 3}) {
+		t.Errorf("g.IsOneWay() doesn't follow the direction of SetOneWay")
+	}
+	if g.HasWall(Coord{1, 2}, Coord{1, 3}) {
+		t.Errorf("g.HasWall((1,2), (1,3)) = true, expected: false")
+	}
+	exp := []Coord{{1, 1}, {1, 2}, {2, 2}, {2, 3}, {1, 3}}
+	if i, s := g.GetFastestPath(); i != 4 || !coordSliceEq(s, exp) {
+		t.Errorf("g.GetFastestPath() = %v, %v; expected: %v, %v", i, s, 4, exp)
+	}
+	if i, _, _ := g.CheapestPath(); i != 4 {
+		t.Errorf("g.CheapestPath() = %v, expected: 4", i)
+	}
+	if i, _, _, _ := g.AStar(Manhattan); i != 4 {
+		t.Errorf("g.AStar(Manhattan) = %v, expected: 4", i)
+	}
+	if n := g.CountShortestPaths(); n.Int64() != 2 {
+		t.Errorf("g.CountShortestPaths() = %v, expected: 2", n)
+	}
+
+	g.AddWall(Coord{1, 1}, Coord{2, 1})
+	g.SetOneWay(Coord{2, 2}, Coord{1, 2})
+	expString := ".-------.-------.-------.\n| ( s )   (1,2) < ( f ) |\n:-------+   ^   +       +\n| (2,1)   (2,2)   (2,3) |\n'-------'-------'-------'\n"
+	if res := g.String(); res != expString {
+		t.Errorf("g.String() = %v, expected: %v", res, expString)
+	}
+	if i, _ := g.GetFastestPath(); i != 0 {
+		t.Errorf("g.GetFastestPath() = %v, expected no path", i)
+	}
+	g.SetObstacle(Coord{1, 2})
+	g.ClearObstacle(Coord{1, 2})
+	if !g.IsOneWay(Coord{2, 2}, Coord{1, 2}) {
+		t.Errorf("ClearObstacle removed the one-way passage")
+	}
+	g.SetOneWay(Coord{1, 2}, Coord{2, 2})
+	if i, _ := g.GetFastestPath(); i != 4 {
+		t.Errorf("g.GetFastestPath() = %v, expected: 4 after turning the passage around", i)
+	}
+	if err := g.ClearOneWay(Coord{1, 2}, Coord{1, 3}); err != nil {
+		t.Errorf("g.ClearOneWay((1,2), (1,3)) = %v, expected: <nil>", err)
+	}
+	if i, _ := g.GetFastestPath(); i != 2 {
+		t.Errorf("g.GetFastestPath() = %v, expected: 2 after ClearOneWay", i)
+	}
+
+	if err := g.SetOneWay(Coord{1, 1}, Coord{1, 3}); !errors.Is(err, ErrNotAdjacent) {
+		t.Errorf("g.SetOneWay((1,1), (1,3)) = %v, expected: %v", err, ErrNotAdjacent)
+	}
+	if err := g.SetOneWay(Coord{1, 1}, Coord{0, 1}); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("g.SetOneWay((1,1), (0,1)) = %v, expected: %v", err, ErrOutOfBounds)
+	}
+
+	diagonal, _ := New(2, 2, WithDiagonals(CornersAllowed))
+	diagonal.AddStart(1, 1)
+	diagonal.AddFinish(2, 2)
+	if err := diagonal.SetOneWay(Coord{2, 2}, Coord{1, 1}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("diagonal.SetOneWay((2,2), (1,1)) = %v, expected: %v", err, ErrUnsupported)
+	}
+	if i, _ := diagonal.GetFastestPath(); i != 1 || diagonal.IsOneWay(Coord{2, 2}, Coord{1, 1}) {
+		t.Errorf("diagonal.GetFastestPath() = %v, expected: 1 through the two-way diagonal", i)
+	}
+	if err := diagonal.SetOneWay(Coord{1, 1}, Coord{1, 2}); err != nil {
+		t.Errorf("diagonal.SetOneWay((1,1), (1,2)) = %v, expected: <nil>", err)
+	}
+	hex, _ := New(2, 2, WithHex())
+	if err := hex.SetOneWay(Coord{2, 1}, Coord{1, 2}); err != nil {
+		t.Errorf("hex.SetOneWay((2,1), (1,2)) = %v, expected: <nil>", err)
+	}
+}
@@ -0,0 +1,81 @@
+package maze
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// The method PathThrough returns the shortest route from the startVertex through
+// the waypoints, in the given order, to the finishVertex and the distance of every
+// leg of the route, i.e. len(waypoints)+1 distances.
+//
+// The route is the concatenation of the shortest path of every leg, so a vertex can be
+// visited more than once. With several start- or finishVertices the first leg starts
+// at the nearest startVertex and the last leg ends at the nearest finishVertex.
+//
+// PathThrough returns an error if a waypoint is outside of the graph or is an obstacle,
+// and an error wrapping ErrNoPath that names the leg if any leg is unreachable.
+func (g *Graph) PathThrough(waypoints ...Coord) ([]Coord, []int, error) {
+	if err := g.checkEndpoints(); err != nil {
+		return nil, nil, err
+	}
+	stops := make([]int, len(waypoints))
+	for k, c := range waypoints {
+		if err := g.checkBounds(c); err != nil {
+			return nil, nil, err
+		}
+		stops[k] = g.index(c)
+		if g.cells[stops[k]]&obstacleFlag != 0 {
+			return nil, nil, fmt.Errorf("%w: waypoint %v", ErrIsObstacle, c)
+		}
+	}
+
+	var route []Coord
+	legs := make([]int, 0, len(stops)+1)
+	sources := g.starts
+	for leg := 0; leg <= len(stops); leg++ {
+		target, from, to := -1, "start", "finish"
+		if leg > 0 {
+			from = waypoints[leg-1].String()
+		}
+		if leg < len(stops) {
+			target, to = stops[leg], waypoints[leg].String()
+		}
+		predecessor, reached, distance := g.bfs(sources, target)
+		if reached < 0 {
+			return nil, nil, fmt.Errorf("%w: leg %d from %v to %v", ErrNoPath, leg+1, from, to)
+		}
+		path := g.tracePath(predecessor, reached)
+		legs = append(legs, distance)
+		if leg > 0 {
+			path = path[1:]
+		}
+		route = append(route, path...)
+		sources = []int{reached}
+	}
+	return route, legs, nil
+}
+
+// The method StringPathThrough returns a ASCII representation of the route returned by
+// PathThrough and its total distance, like StringFastestPath.
+//
+// The waypoints are numbered in the order they're visited and are displayed
+// as: ( 1 ), ( 2 ), ..., where the numbers from 1000 are shortened to their last
+// 2 digits like the steps of StringTimedPath, e.g. (~00) for the 1000th waypoint.
+func (g *Graph) StringPathThrough(waypoints ...Coord) string {
+	route, legs, err := g.PathThrough(waypoints...)
+	marks := make(map[int]string, len(route))
+	for _, c := range route {
+		marks[g.index(c)] = "p"
+	}
+	distance := 0
+	for _, d := range legs {
+		distance += d
+	}
+	if err == nil {
+		for k, c := range waypoints {
+			marks[g.index(c)] = strconv.Itoa(k + 1)
+		}
+	}
+	return g.stringMarks(marks, route, "distance =", distance, err)
+}
@@ -0,0 +1,64 @@
+package maze
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPathThrough(t *testing.T) {
+	g := NewGraph(3, 5)
+	g.AddStart(1, 1)
+	g.AddFinish(1, 5)
+
+	route, legs, err := g.PathThrough(Coord{3, 3}, Coord{1, 3})
+	expLegs := []int{4, 2, 2}
+	if err != nil || len(route) != 9 || route[4] != (Coord{3, 3}) || route[6] != (Coord{1, 3}) || route[8] != (Coord{1, 5}) {
+		t.Errorf("g.PathThrough((3,3), (1,3)) = %v, %v, %v; expected a route through (3,3) and (1,3)", route, legs, err)
+	}
+	if len(legs) != len(expLegs) || legs[0] != expLegs[0] || legs[1] != expLegs[1] || legs[2] != expLegs[2] {
+		t.Errorf("g.PathThrough((3,3), (1,3)) legs = %v, expected: %v", legs, expLegs)
+	}
+
+	dist, path := g.GetFastestPath()
+	route, legs, err = g.PathThrough()
+	if err != nil || len(legs) != 1 || legs[0] != dist || !coordSliceEq(route, path) {
+		t.Errorf("g.PathThrough() = %v, %v, %v; expected: %v, [%v], <nil>", route, legs, err, path, dist)
+	}
+
+	res := g.StringPathThrough(Coord{3, 3}, Coord{1, 3})
+	if !strings.Contains(res, "( 1 )") || !strings.Contains(res, "( 2 )") || !strings.HasSuffix(res, "distance =8") {
+		t.Errorf("g.StringPathThrough((3,3), (1,3)) = %v, expected numbered waypoints and distance =8", res)
+	}
+
+	g.AddObstacle(2, 4)
+	g.AddObstacle(3, 4)
+	g.AddObstacle(2, 5)
+	if _, _, err := g.PathThrough(Coord{3, 5}); !errors.Is(err, ErrNoPath) || !strings.Contains(err.Error(), "leg 1 from start to (3,5)") {
+		t.Errorf("g.PathThrough((3,5)) error = %v, expected: %v for leg 1", err, ErrNoPath)
+	}
+	if _, _, err := g.PathThrough(Coord{2, 4}); !errors.Is(err, ErrIsObstacle) {
+		t.Errorf("g.PathThrough((2,4)) error = %v, expected: %v", err, ErrIsObstacle)
+	}
+	if _, _, err := g.PathThrough(Coord{0, 4}); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("g.PathThrough((0,4)) error = %v, expected: %v", err, ErrOutOfBounds)
+	}
+
+	long, _ := New(1, 1003)
+	long.AddStart(1, 1)
+	long.AddFinish(1, 1003)
+	var waypoints []Coord
+	for x := 2; x < 1003; x++ {
+		waypoints = append(waypoints, Coord{1, x})
+	}
+	res = long.StringPathThrough(waypoints...)
+	lines := strings.Split(res, "\n")
+	if len(lines[2]) != len(lines[1]) {
+		t.Errorf("long.StringPathThrough() isn't aligned: %q and %q", lines[1][:50], lines[2][:50])
+	}
+	for _, label := range []string{"( 1 )", "(999)", "(~00)", "(~01)"} {
+		if !strings.Contains(lines[2], label) {
+			t.Errorf("long.StringPathThrough() doesn't display %v", label)
+		}
+	}
+}